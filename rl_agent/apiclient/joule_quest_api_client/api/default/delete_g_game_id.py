@@ -28,6 +28,11 @@ def _parse_response(*, client: AuthenticatedClient | Client, response: httpx.Res
         response_200 = cast(Any, None)
         return response_200
 
+    if response.status_code == 401:
+        response_401 = Error.from_dict(response.json())
+
+        return response_401
+
     if response.status_code == 500:
         response_500 = Error.from_dict(response.json())
 
@@ -53,7 +58,7 @@ def sync_detailed(
     *,
     client: AuthenticatedClient | Client,
 ) -> Response[Any | Error]:
-    """Let the server clean up state for the given game
+    """Let the server clean up state for the given game. Games with seats can only be deleted with a seat token
 
     Args:
         game_id (str):
@@ -82,7 +87,7 @@ def sync(
     *,
     client: AuthenticatedClient | Client,
 ) -> Any | Error | None:
-    """Let the server clean up state for the given game
+    """Let the server clean up state for the given game. Games with seats can only be deleted with a seat token
 
     Args:
         game_id (str):
@@ -106,7 +111,7 @@ async def asyncio_detailed(
     *,
     client: AuthenticatedClient | Client,
 ) -> Response[Any | Error]:
-    """Let the server clean up state for the given game
+    """Let the server clean up state for the given game. Games with seats can only be deleted with a seat token
 
     Args:
         game_id (str):
@@ -133,7 +138,7 @@ async def asyncio(
     *,
     client: AuthenticatedClient | Client,
 ) -> Any | Error | None:
-    """Let the server clean up state for the given game
+    """Let the server clean up state for the given game. Games with seats can only be deleted with a seat token
 
     Args:
         game_id (str):
@@ -0,0 +1,206 @@
+from http import HTTPStatus
+from typing import Any, cast
+from urllib.parse import quote
+
+import httpx
+
+from ... import errors
+from ...client import AuthenticatedClient, Client
+from ...models.error import Error
+from ...types import UNSET, Response, Unset
+
+
+def _get_kwargs(
+    game_id: str,
+    *,
+    offset: int | Unset = UNSET,
+) -> dict[str, Any]:
+    params: dict[str, Any] = {}
+
+    params["offset"] = offset
+
+    params = {k: v for k, v in params.items() if v is not UNSET and v is not None}
+
+    _kwargs: dict[str, Any] = {
+        "method": "get",
+        "url": "/g/{game_id}/events".format(
+            game_id=quote(str(game_id), safe=""),
+        ),
+        "params": params,
+    }
+
+    return _kwargs
+
+
+def _parse_response(*, client: AuthenticatedClient | Client, response: httpx.Response) -> Error | str | None:
+    if response.status_code == 200:
+        response_200 = cast(str, response.content)
+        return response_200
+
+    if response.status_code == 400:
+        response_400 = Error.from_dict(response.json())
+
+        return response_400
+
+    if response.status_code == 401:
+        response_401 = Error.from_dict(response.json())
+
+        return response_401
+
+    if response.status_code == 404:
+        response_404 = Error.from_dict(response.json())
+
+        return response_404
+
+    if response.status_code == 500:
+        response_500 = Error.from_dict(response.json())
+
+        return response_500
+
+    if client.raise_on_unexpected_status:
+        raise errors.UnexpectedStatus(response.status_code, response.content)
+    else:
+        return None
+
+
+def _build_response(*, client: AuthenticatedClient | Client, response: httpx.Response) -> Response[Error | str]:
+    return Response(
+        status_code=HTTPStatus(response.status_code),
+        content=response.content,
+        headers=response.headers,
+        parsed=_parse_response(client=client, response=response),
+    )
+
+
+def sync_detailed(
+    game_id: str,
+    *,
+    client: AuthenticatedClient | Client,
+    offset: int | Unset = UNSET,
+) -> Response[Error | str]:
+    """Stream the game as server-sent events. Each "log" event holds one event from the game log, and each "state" event
+    holds the game state (as in a GameUpdate, without rewards) once all log events from a change have been sent. An
+    event's id is the offset to resume from after it, and reconnecting clients may send it in the Last-Event-ID header
+    instead of the offset parameter. The stream ends after the final state of a finished game, or when the game is
+    deleted. Under a visibility policy other than Full, both kinds of event show what the seat of the token may see
+
+    Args:
+        game_id (str):
+        offset (int | Unset): Index of the first log event to send. Defaults to 0, the start of the game
+
+    Raises:
+        errors.UnexpectedStatus: If the server returns an undocumented status code and Client.raise_on_unexpected_status is True.
+        httpx.TimeoutException: If the request takes longer than Client.timeout.
+
+    Returns:
+        Response[Error | str]
+    """
+
+    kwargs = _get_kwargs(
+        game_id=game_id,
+        offset=offset,
+    )
+
+    response = client.get_httpx_client().request(
+        **kwargs,
+    )
+
+    return _build_response(client=client, response=response)
+
+
+def sync(
+    game_id: str,
+    *,
+    client: AuthenticatedClient | Client,
+    offset: int | Unset = UNSET,
+) -> Error | str | None:
+    """Stream the game as server-sent events. Each "log" event holds one event from the game log, and each "state" event
+    holds the game state (as in a GameUpdate, without rewards) once all log events from a change have been sent. An
+    event's id is the offset to resume from after it, and reconnecting clients may send it in the Last-Event-ID header
+    instead of the offset parameter. The stream ends after the final state of a finished game, or when the game is
+    deleted. Under a visibility policy other than Full, both kinds of event show what the seat of the token may see
+
+    Args:
+        game_id (str):
+        offset (int | Unset): Index of the first log event to send. Defaults to 0, the start of the game
+
+    Raises:
+        errors.UnexpectedStatus: If the server returns an undocumented status code and Client.raise_on_unexpected_status is True.
+        httpx.TimeoutException: If the request takes longer than Client.timeout.
+
+    Returns:
+        Error | str
+    """
+
+    return sync_detailed(
+        game_id=game_id,
+        client=client,
+        offset=offset,
+    ).parsed
+
+
+async def asyncio_detailed(
+    game_id: str,
+    *,
+    client: AuthenticatedClient | Client,
+    offset: int | Unset = UNSET,
+) -> Response[Error | str]:
+    """Stream the game as server-sent events. Each "log" event holds one event from the game log, and each "state" event
+    holds the game state (as in a GameUpdate, without rewards) once all log events from a change have been sent. An
+    event's id is the offset to resume from after it, and reconnecting clients may send it in the Last-Event-ID header
+    instead of the offset parameter. The stream ends after the final state of a finished game, or when the game is
+    deleted. Under a visibility policy other than Full, both kinds of event show what the seat of the token may see
+
+    Args:
+        game_id (str):
+        offset (int | Unset): Index of the first log event to send. Defaults to 0, the start of the game
+
+    Raises:
+        errors.UnexpectedStatus: If the server returns an undocumented status code and Client.raise_on_unexpected_status is True.
+        httpx.TimeoutException: If the request takes longer than Client.timeout.
+
+    Returns:
+        Response[Error | str]
+    """
+
+    kwargs = _get_kwargs(
+        game_id=game_id,
+        offset=offset,
+    )
+
+    response = await client.get_async_httpx_client().request(**kwargs)
+
+    return _build_response(client=client, response=response)
+
+
+async def asyncio(
+    game_id: str,
+    *,
+    client: AuthenticatedClient | Client,
+    offset: int | Unset = UNSET,
+) -> Error | str | None:
+    """Stream the game as server-sent events. Each "log" event holds one event from the game log, and each "state" event
+    holds the game state (as in a GameUpdate, without rewards) once all log events from a change have been sent. An
+    event's id is the offset to resume from after it, and reconnecting clients may send it in the Last-Event-ID header
+    instead of the offset parameter. The stream ends after the final state of a finished game, or when the game is
+    deleted. Under a visibility policy other than Full, both kinds of event show what the seat of the token may see
+
+    Args:
+        game_id (str):
+        offset (int | Unset): Index of the first log event to send. Defaults to 0, the start of the game
+
+    Raises:
+        errors.UnexpectedStatus: If the server returns an undocumented status code and Client.raise_on_unexpected_status is True.
+        httpx.TimeoutException: If the request takes longer than Client.timeout.
+
+    Returns:
+        Error | str
+    """
+
+    return (
+        await asyncio_detailed(
+            game_id=game_id,
+            client=client,
+            offset=offset,
+        )
+    ).parsed
@@ -7,17 +7,57 @@ import httpx
 from ... import errors
 from ...client import AuthenticatedClient, Client
 from ...models.error import Error
-from ...types import Response
+from ...types import UNSET, Response, Unset
 
 
 def _get_kwargs(
     game_id: str,
+    *,
+    game_event: list[str] | Unset = UNSET,
+    round_: list[int] | Unset = UNSET,
+    player_index: list[int] | Unset = UNSET,
+    state: list[str] | Unset = UNSET,
+    offset: int | Unset = 0,
+    limit: int | Unset = 0,
 ) -> dict[str, Any]:
+    params: dict[str, Any] = {}
+
+    json_game_event: list[str] | Unset = UNSET
+    if not isinstance(game_event, Unset):
+        json_game_event = game_event
+
+    params["game_event"] = json_game_event
+
+    json_round_: list[int] | Unset = UNSET
+    if not isinstance(round_, Unset):
+        json_round_ = round_
+
+    params["round"] = json_round_
+
+    json_player_index: list[int] | Unset = UNSET
+    if not isinstance(player_index, Unset):
+        json_player_index = player_index
+
+    params["player_index"] = json_player_index
+
+    json_state: list[str] | Unset = UNSET
+    if not isinstance(state, Unset):
+        json_state = state
+
+    params["state"] = json_state
+
+    params["offset"] = offset
+
+    params["limit"] = limit
+
+    params = {k: v for k, v in params.items() if v is not UNSET and v is not None}
+
     _kwargs: dict[str, Any] = {
         "method": "get",
         "url": "/g/{game_id}/log".format(
             game_id=quote(str(game_id), safe=""),
         ),
+        "params": params,
     }
 
     return _kwargs
@@ -28,6 +68,16 @@ def _parse_response(*, client: AuthenticatedClient | Client, response: httpx.Res
         response_200 = cast(str, response.content)
         return response_200
 
+    if response.status_code == 400:
+        response_400 = Error.from_dict(response.json())
+
+        return response_400
+
+    if response.status_code == 401:
+        response_401 = Error.from_dict(response.json())
+
+        return response_401
+
     if response.status_code == 404:
         response_404 = Error.from_dict(response.json())
 
@@ -57,11 +107,27 @@ def sync_detailed(
     game_id: str,
     *,
     client: AuthenticatedClient | Client,
+    game_event: list[str] | Unset = UNSET,
+    round_: list[int] | Unset = UNSET,
+    player_index: list[int] | Unset = UNSET,
+    state: list[str] | Unset = UNSET,
+    offset: int | Unset = 0,
+    limit: int | Unset = 0,
 ) -> Response[Error | str]:
-    """Get the event log for the given game
+    """Get the event log for the given game. The filter parameters may each be repeated to accept several values, and
+    events must match every filter that is given. offset and limit page through the matching events. Under a visibility
+    policy other than Full, the log shows what the seat of the token may see
 
     Args:
         game_id (str):
+        game_event (list[str] | Unset): Only return events of these types, e.g. MarketOutcome
+        round_ (list[int] | Unset): Only return events from these rounds
+        player_index (list[int] | Unset): Only return events about these players, including their actions
+        state (list[str] | Unset): Only return events logged in these state machine states, e.g.
+            StateMachineStateOperatePhase
+        offset (int | Unset): Number of matching events to skip Default: 0.
+        limit (int | Unset): Maximum number of events to return. All matching events are returned if this is 0 Default:
+            0.
 
     Raises:
         errors.UnexpectedStatus: If the server returns an undocumented status code and Client.raise_on_unexpected_status is True.
@@ -73,6 +139,12 @@ def sync_detailed(
 
     kwargs = _get_kwargs(
         game_id=game_id,
+        game_event=game_event,
+        round_=round_,
+        player_index=player_index,
+        state=state,
+        offset=offset,
+        limit=limit,
     )
 
     response = client.get_httpx_client().request(
@@ -86,11 +158,27 @@ def sync(
     game_id: str,
     *,
     client: AuthenticatedClient | Client,
+    game_event: list[str] | Unset = UNSET,
+    round_: list[int] | Unset = UNSET,
+    player_index: list[int] | Unset = UNSET,
+    state: list[str] | Unset = UNSET,
+    offset: int | Unset = 0,
+    limit: int | Unset = 0,
 ) -> Error | str | None:
-    """Get the event log for the given game
+    """Get the event log for the given game. The filter parameters may each be repeated to accept several values, and
+    events must match every filter that is given. offset and limit page through the matching events. Under a visibility
+    policy other than Full, the log shows what the seat of the token may see
 
     Args:
         game_id (str):
+        game_event (list[str] | Unset): Only return events of these types, e.g. MarketOutcome
+        round_ (list[int] | Unset): Only return events from these rounds
+        player_index (list[int] | Unset): Only return events about these players, including their actions
+        state (list[str] | Unset): Only return events logged in these state machine states, e.g.
+            StateMachineStateOperatePhase
+        offset (int | Unset): Number of matching events to skip Default: 0.
+        limit (int | Unset): Maximum number of events to return. All matching events are returned if this is 0 Default:
+            0.
 
     Raises:
         errors.UnexpectedStatus: If the server returns an undocumented status code and Client.raise_on_unexpected_status is True.
@@ -102,6 +190,12 @@ def sync(
 
     return sync_detailed(
         game_id=game_id,
+        game_event=game_event,
+        round_=round_,
+        player_index=player_index,
+        state=state,
+        offset=offset,
+        limit=limit,
         client=client,
     ).parsed
 
@@ -110,11 +204,27 @@ async def asyncio_detailed(
     game_id: str,
     *,
     client: AuthenticatedClient | Client,
+    game_event: list[str] | Unset = UNSET,
+    round_: list[int] | Unset = UNSET,
+    player_index: list[int] | Unset = UNSET,
+    state: list[str] | Unset = UNSET,
+    offset: int | Unset = 0,
+    limit: int | Unset = 0,
 ) -> Response[Error | str]:
-    """Get the event log for the given game
+    """Get the event log for the given game. The filter parameters may each be repeated to accept several values, and
+    events must match every filter that is given. offset and limit page through the matching events. Under a visibility
+    policy other than Full, the log shows what the seat of the token may see
 
     Args:
         game_id (str):
+        game_event (list[str] | Unset): Only return events of these types, e.g. MarketOutcome
+        round_ (list[int] | Unset): Only return events from these rounds
+        player_index (list[int] | Unset): Only return events about these players, including their actions
+        state (list[str] | Unset): Only return events logged in these state machine states, e.g.
+            StateMachineStateOperatePhase
+        offset (int | Unset): Number of matching events to skip Default: 0.
+        limit (int | Unset): Maximum number of events to return. All matching events are returned if this is 0 Default:
+            0.
 
     Raises:
         errors.UnexpectedStatus: If the server returns an undocumented status code and Client.raise_on_unexpected_status is True.
@@ -126,6 +236,12 @@ async def asyncio_detailed(
 
     kwargs = _get_kwargs(
         game_id=game_id,
+        game_event=game_event,
+        round_=round_,
+        player_index=player_index,
+        state=state,
+        offset=offset,
+        limit=limit,
     )
 
     response = await client.get_async_httpx_client().request(**kwargs)
@@ -137,11 +253,27 @@ async def asyncio(
     game_id: str,
     *,
     client: AuthenticatedClient | Client,
+    game_event: list[str] | Unset = UNSET,
+    round_: list[int] | Unset = UNSET,
+    player_index: list[int] | Unset = UNSET,
+    state: list[str] | Unset = UNSET,
+    offset: int | Unset = 0,
+    limit: int | Unset = 0,
 ) -> Error | str | None:
-    """Get the event log for the given game
+    """Get the event log for the given game. The filter parameters may each be repeated to accept several values, and
+    events must match every filter that is given. offset and limit page through the matching events. Under a visibility
+    policy other than Full, the log shows what the seat of the token may see
 
     Args:
         game_id (str):
+        game_event (list[str] | Unset): Only return events of these types, e.g. MarketOutcome
+        round_ (list[int] | Unset): Only return events from these rounds
+        player_index (list[int] | Unset): Only return events about these players, including their actions
+        state (list[str] | Unset): Only return events logged in these state machine states, e.g.
+            StateMachineStateOperatePhase
+        offset (int | Unset): Number of matching events to skip Default: 0.
+        limit (int | Unset): Maximum number of events to return. All matching events are returned if this is 0 Default:
+            0.
 
     Raises:
         errors.UnexpectedStatus: If the server returns an undocumented status code and Client.raise_on_unexpected_status is True.
@@ -154,6 +286,12 @@ async def asyncio(
     return (
         await asyncio_detailed(
             game_id=game_id,
+        game_event=game_event,
+        round_=round_,
+        player_index=player_index,
+        state=state,
+        offset=offset,
+        limit=limit,
             client=client,
         )
     ).parsed
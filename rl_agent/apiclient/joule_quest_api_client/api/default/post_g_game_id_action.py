@@ -45,11 +45,31 @@ def _parse_response(*, client: AuthenticatedClient | Client, response: httpx.Res
 
         return response_400
 
+    if response.status_code == 401:
+        response_401 = Error.from_dict(response.json())
+
+        return response_401
+
+    if response.status_code == 403:
+        response_403 = Error.from_dict(response.json())
+
+        return response_403
+
     if response.status_code == 404:
         response_404 = Error.from_dict(response.json())
 
         return response_404
 
+    if response.status_code == 409:
+        response_409 = Error.from_dict(response.json())
+
+        return response_409
+
+    if response.status_code == 422:
+        response_422 = Error.from_dict(response.json())
+
+        return response_422
+
     if response.status_code == 500:
         response_500 = Error.from_dict(response.json())
 
@@ -76,7 +96,9 @@ def sync_detailed(
     client: AuthenticatedClient | Client,
     body: PlayerAction,
 ) -> Response[Error | GameUpdate]:
-    """Post an action to the given game
+    """Post an action to the given game. Invalid actions leave the game unchanged and return 422 with the legal
+    actions. Returns 409 if the game is still handling a previous action. In games with seats, the action must be
+    authorized with the seat token of its PlayerIndex
 
     Args:
         game_id (str):
@@ -108,7 +130,9 @@ def sync(
     client: AuthenticatedClient | Client,
     body: PlayerAction,
 ) -> Error | GameUpdate | None:
-    """Post an action to the given game
+    """Post an action to the given game. Invalid actions leave the game unchanged and return 422 with the legal
+    actions. Returns 409 if the game is still handling a previous action. In games with seats, the action must be
+    authorized with the seat token of its PlayerIndex
 
     Args:
         game_id (str):
@@ -135,7 +159,9 @@ async def asyncio_detailed(
     client: AuthenticatedClient | Client,
     body: PlayerAction,
 ) -> Response[Error | GameUpdate]:
-    """Post an action to the given game
+    """Post an action to the given game. Invalid actions leave the game unchanged and return 422 with the legal
+    actions. Returns 409 if the game is still handling a previous action. In games with seats, the action must be
+    authorized with the seat token of its PlayerIndex
 
     Args:
         game_id (str):
@@ -165,7 +191,9 @@ async def asyncio(
     client: AuthenticatedClient | Client,
     body: PlayerAction,
 ) -> Error | GameUpdate | None:
-    """Post an action to the given game
+    """Post an action to the given game. Invalid actions leave the game unchanged and return 422 with the legal
+    actions. Returns 409 if the game is still handling a previous action. In games with seats, the action must be
+    authorized with the seat token of its PlayerIndex
 
     Args:
         game_id (str):
@@ -0,0 +1,214 @@
+from http import HTTPStatus
+from typing import Any
+from urllib.parse import quote
+
+import httpx
+
+from ... import errors
+from ...client import AuthenticatedClient, Client
+from ...models.error import Error
+from ...models.batch_update import BatchUpdate
+from ...models.player_action import PlayerAction
+from ...types import Response
+
+
+def _get_kwargs(
+    game_id: str,
+    *,
+    body: list[PlayerAction],
+) -> dict[str, Any]:
+    headers: dict[str, Any] = {}
+
+    _kwargs: dict[str, Any] = {
+        "method": "post",
+        "url": "/g/{game_id}/actions".format(
+            game_id=quote(str(game_id), safe=""),
+        ),
+    }
+
+    _kwargs["json"] = []
+    for body_item_data in body:
+        body_item = body_item_data.to_dict()
+        _kwargs["json"].append(body_item)
+
+
+    headers["Content-Type"] = "application/json"
+
+    _kwargs["headers"] = headers
+    return _kwargs
+
+
+def _parse_response(*, client: AuthenticatedClient | Client, response: httpx.Response) -> Error | BatchUpdate | None:
+    if response.status_code == 200:
+        response_200 = BatchUpdate.from_dict(response.json())
+
+        return response_200
+
+    if response.status_code == 400:
+        response_400 = Error.from_dict(response.json())
+
+        return response_400
+
+    if response.status_code == 401:
+        response_401 = Error.from_dict(response.json())
+
+        return response_401
+
+    if response.status_code == 404:
+        response_404 = Error.from_dict(response.json())
+
+        return response_404
+
+    if response.status_code == 409:
+        response_409 = Error.from_dict(response.json())
+
+        return response_409
+
+    if response.status_code == 500:
+        response_500 = Error.from_dict(response.json())
+
+        return response_500
+
+    if client.raise_on_unexpected_status:
+        raise errors.UnexpectedStatus(response.status_code, response.content)
+    else:
+        return None
+
+
+def _build_response(*, client: AuthenticatedClient | Client, response: httpx.Response) -> Response[Error | BatchUpdate]:
+    return Response(
+        status_code=HTTPStatus(response.status_code),
+        content=response.content,
+        headers=response.headers,
+        parsed=_parse_response(client=client, response=response),
+    )
+
+
+def sync_detailed(
+    game_id: str,
+    *,
+    client: AuthenticatedClient | Client,
+    body: list[PlayerAction],
+) -> Response[Error | BatchUpdate]:
+    """Post a list of actions to the given game, which are applied in order until one is rejected or every player has
+    finished building. The remaining actions are skipped. Rejected actions are reported in the results rather than
+    by the response status. Returns 409 if the game is still handling another request. In games with seats, the
+    request must be authorized with a seat token, and actions for other seats are rejected
+
+    Args:
+        game_id (str):
+        body (list[PlayerAction]):
+
+    Raises:
+        errors.UnexpectedStatus: If the server returns an undocumented status code and Client.raise_on_unexpected_status is True.
+        httpx.TimeoutException: If the request takes longer than Client.timeout.
+
+    Returns:
+        Response[Error | BatchUpdate]
+    """
+
+    kwargs = _get_kwargs(
+        game_id=game_id,
+        body=body,
+    )
+
+    response = client.get_httpx_client().request(
+        **kwargs,
+    )
+
+    return _build_response(client=client, response=response)
+
+
+def sync(
+    game_id: str,
+    *,
+    client: AuthenticatedClient | Client,
+    body: list[PlayerAction],
+) -> Error | BatchUpdate | None:
+    """Post a list of actions to the given game, which are applied in order until one is rejected or every player has
+    finished building. The remaining actions are skipped. Rejected actions are reported in the results rather than
+    by the response status. Returns 409 if the game is still handling another request. In games with seats, the
+    request must be authorized with a seat token, and actions for other seats are rejected
+
+    Args:
+        game_id (str):
+        body (list[PlayerAction]):
+
+    Raises:
+        errors.UnexpectedStatus: If the server returns an undocumented status code and Client.raise_on_unexpected_status is True.
+        httpx.TimeoutException: If the request takes longer than Client.timeout.
+
+    Returns:
+        Error | BatchUpdate
+    """
+
+    return sync_detailed(
+        game_id=game_id,
+        client=client,
+        body=body,
+    ).parsed
+
+
+async def asyncio_detailed(
+    game_id: str,
+    *,
+    client: AuthenticatedClient | Client,
+    body: list[PlayerAction],
+) -> Response[Error | BatchUpdate]:
+    """Post a list of actions to the given game, which are applied in order until one is rejected or every player has
+    finished building. The remaining actions are skipped. Rejected actions are reported in the results rather than
+    by the response status. Returns 409 if the game is still handling another request. In games with seats, the
+    request must be authorized with a seat token, and actions for other seats are rejected
+
+    Args:
+        game_id (str):
+        body (list[PlayerAction]):
+
+    Raises:
+        errors.UnexpectedStatus: If the server returns an undocumented status code and Client.raise_on_unexpected_status is True.
+        httpx.TimeoutException: If the request takes longer than Client.timeout.
+
+    Returns:
+        Response[Error | BatchUpdate]
+    """
+
+    kwargs = _get_kwargs(
+        game_id=game_id,
+        body=body,
+    )
+
+    response = await client.get_async_httpx_client().request(**kwargs)
+
+    return _build_response(client=client, response=response)
+
+
+async def asyncio(
+    game_id: str,
+    *,
+    client: AuthenticatedClient | Client,
+    body: list[PlayerAction],
+) -> Error | BatchUpdate | None:
+    """Post a list of actions to the given game, which are applied in order until one is rejected or every player has
+    finished building. The remaining actions are skipped. Rejected actions are reported in the results rather than
+    by the response status. Returns 409 if the game is still handling another request. In games with seats, the
+    request must be authorized with a seat token, and actions for other seats are rejected
+
+    Args:
+        game_id (str):
+        body (list[PlayerAction]):
+
+    Raises:
+        errors.UnexpectedStatus: If the server returns an undocumented status code and Client.raise_on_unexpected_status is True.
+        httpx.TimeoutException: If the request takes longer than Client.timeout.
+
+    Returns:
+        Error | BatchUpdate
+    """
+
+    return (
+        await asyncio_detailed(
+            game_id=game_id,
+            client=client,
+            body=body,
+        )
+    ).parsed
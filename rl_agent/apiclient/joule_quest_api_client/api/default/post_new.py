@@ -6,18 +6,36 @@ import httpx
 from ... import errors
 from ...client import AuthenticatedClient, Client
 from ...models.error import Error
+from ...models.game_parameters import GameParameters
 from ...models.game_update import GameUpdate
-from ...types import UNSET, Response
+from ...models.post_new_visibility import PostNewVisibility
+from ...types import UNSET, Response, Unset
 
 
 def _get_kwargs(
     *,
+    body: GameParameters | Unset = UNSET,
     num_players: int,
+    seed: int | Unset = UNSET,
+    seat_tokens: bool | Unset = False,
+    visibility: PostNewVisibility | Unset = PostNewVisibility.FULL,
 ) -> dict[str, Any]:
+    headers: dict[str, Any] = {}
+
     params: dict[str, Any] = {}
 
     params["numPlayers"] = num_players
 
+    params["seed"] = seed
+
+    params["seatTokens"] = seat_tokens
+
+    json_visibility: str | Unset = UNSET
+    if not isinstance(visibility, Unset):
+        json_visibility = visibility.value
+
+    params["visibility"] = json_visibility
+
     params = {k: v for k, v in params.items() if v is not UNSET and v is not None}
 
     _kwargs: dict[str, Any] = {
@@ -26,6 +44,12 @@ def _get_kwargs(
         "params": params,
     }
 
+    if not isinstance(body, Unset):
+        _kwargs["json"] = body.to_dict()
+
+    headers["Content-Type"] = "application/json"
+
+    _kwargs["headers"] = headers
     return _kwargs
 
 
@@ -63,12 +87,25 @@ def _build_response(*, client: AuthenticatedClient | Client, response: httpx.Res
 def sync_detailed(
     *,
     client: AuthenticatedClient | Client,
+    body: GameParameters | Unset = UNSET,
     num_players: int,
+    seed: int | Unset = UNSET,
+    seat_tokens: bool | Unset = False,
+    visibility: PostNewVisibility | Unset = PostNewVisibility.FULL,
 ) -> Response[Error | GameUpdate]:
     """Create a new game
 
     Args:
         num_players (int):
+        seed (int | Unset):
+        seat_tokens (bool | Unset):  Default: False.
+        visibility (PostNewVisibility | Unset): Policy for what each seat sees of the other players' state. Opponents'
+            money reads as 0 under HiddenMoney, and opponents' asset counts are rounded down to 0, 1, 3 or 6 under
+            BucketedMix. Requests without a seat token see every player as an opponent. The policy applies to the game
+            log and event stream too
+            Default: PostNewVisibility.FULL.
+        body (GameParameters | Unset): Full or partial game parameters. Fields that are not set take
+            their default values
 
     Raises:
         errors.UnexpectedStatus: If the server returns an undocumented status code and Client.raise_on_unexpected_status is True.
@@ -79,7 +116,11 @@ def sync_detailed(
     """
 
     kwargs = _get_kwargs(
+        body=body,
         num_players=num_players,
+        seed=seed,
+        seat_tokens=seat_tokens,
+        visibility=visibility,
     )
 
     response = client.get_httpx_client().request(
@@ -92,12 +133,25 @@ def sync_detailed(
 def sync(
     *,
     client: AuthenticatedClient | Client,
+    body: GameParameters | Unset = UNSET,
     num_players: int,
+    seed: int | Unset = UNSET,
+    seat_tokens: bool | Unset = False,
+    visibility: PostNewVisibility | Unset = PostNewVisibility.FULL,
 ) -> Error | GameUpdate | None:
     """Create a new game
 
     Args:
         num_players (int):
+        seed (int | Unset):
+        seat_tokens (bool | Unset):  Default: False.
+        visibility (PostNewVisibility | Unset): Policy for what each seat sees of the other players' state. Opponents'
+            money reads as 0 under HiddenMoney, and opponents' asset counts are rounded down to 0, 1, 3 or 6 under
+            BucketedMix. Requests without a seat token see every player as an opponent. The policy applies to the game
+            log and event stream too
+            Default: PostNewVisibility.FULL.
+        body (GameParameters | Unset): Full or partial game parameters. Fields that are not set take
+            their default values
 
     Raises:
         errors.UnexpectedStatus: If the server returns an undocumented status code and Client.raise_on_unexpected_status is True.
@@ -109,19 +163,36 @@ def sync(
 
     return sync_detailed(
         client=client,
+        body=body,
         num_players=num_players,
+        seed=seed,
+        seat_tokens=seat_tokens,
+        visibility=visibility,
     ).parsed
 
 
 async def asyncio_detailed(
     *,
     client: AuthenticatedClient | Client,
+    body: GameParameters | Unset = UNSET,
     num_players: int,
+    seed: int | Unset = UNSET,
+    seat_tokens: bool | Unset = False,
+    visibility: PostNewVisibility | Unset = PostNewVisibility.FULL,
 ) -> Response[Error | GameUpdate]:
     """Create a new game
 
     Args:
         num_players (int):
+        seed (int | Unset):
+        seat_tokens (bool | Unset):  Default: False.
+        visibility (PostNewVisibility | Unset): Policy for what each seat sees of the other players' state. Opponents'
+            money reads as 0 under HiddenMoney, and opponents' asset counts are rounded down to 0, 1, 3 or 6 under
+            BucketedMix. Requests without a seat token see every player as an opponent. The policy applies to the game
+            log and event stream too
+            Default: PostNewVisibility.FULL.
+        body (GameParameters | Unset): Full or partial game parameters. Fields that are not set take
+            their default values
 
     Raises:
         errors.UnexpectedStatus: If the server returns an undocumented status code and Client.raise_on_unexpected_status is True.
@@ -132,7 +203,11 @@ async def asyncio_detailed(
     """
 
     kwargs = _get_kwargs(
+        body=body,
         num_players=num_players,
+        seed=seed,
+        seat_tokens=seat_tokens,
+        visibility=visibility,
     )
 
     response = await client.get_async_httpx_client().request(**kwargs)
@@ -143,12 +218,25 @@ async def asyncio_detailed(
 async def asyncio(
     *,
     client: AuthenticatedClient | Client,
+    body: GameParameters | Unset = UNSET,
     num_players: int,
+    seed: int | Unset = UNSET,
+    seat_tokens: bool | Unset = False,
+    visibility: PostNewVisibility | Unset = PostNewVisibility.FULL,
 ) -> Error | GameUpdate | None:
     """Create a new game
 
     Args:
         num_players (int):
+        seed (int | Unset):
+        seat_tokens (bool | Unset):  Default: False.
+        visibility (PostNewVisibility | Unset): Policy for what each seat sees of the other players' state. Opponents'
+            money reads as 0 under HiddenMoney, and opponents' asset counts are rounded down to 0, 1, 3 or 6 under
+            BucketedMix. Requests without a seat token see every player as an opponent. The policy applies to the game
+            log and event stream too
+            Default: PostNewVisibility.FULL.
+        body (GameParameters | Unset): Full or partial game parameters. Fields that are not set take
+            their default values
 
     Raises:
         errors.UnexpectedStatus: If the server returns an undocumented status code and Client.raise_on_unexpected_status is True.
@@ -162,5 +250,8 @@ async def asyncio(
         await asyncio_detailed(
             client=client,
             num_players=num_players,
+        seed=seed,
+        seat_tokens=seat_tokens,
+        visibility=visibility,
         )
     ).parsed
@@ -0,0 +1,179 @@
+from http import HTTPStatus
+from typing import Any
+
+import httpx
+
+from ... import errors
+from ...client import AuthenticatedClient, Client
+from ...models.error import Error
+from ...models.game_parameters import GameParameters
+from ...models.params_validation import ParamsValidation
+from ...types import UNSET, Response, Unset
+
+
+def _get_kwargs(
+    *,
+    body: GameParameters | Unset = UNSET,
+) -> dict[str, Any]:
+    headers: dict[str, Any] = {}
+
+    _kwargs: dict[str, Any] = {
+        "method": "post",
+        "url": "/params/validate",
+    }
+
+    if not isinstance(body, Unset):
+        _kwargs["json"] = body.to_dict()
+
+    headers["Content-Type"] = "application/json"
+
+    _kwargs["headers"] = headers
+    return _kwargs
+
+
+def _parse_response(
+    *, client: AuthenticatedClient | Client, response: httpx.Response
+) -> Error | ParamsValidation | None:
+    if response.status_code == 200:
+        response_200 = ParamsValidation.from_dict(response.json())
+
+        return response_200
+
+    if response.status_code == 400:
+        response_400 = Error.from_dict(response.json())
+
+        return response_400
+
+    if response.status_code == 500:
+        response_500 = Error.from_dict(response.json())
+
+        return response_500
+
+    if client.raise_on_unexpected_status:
+        raise errors.UnexpectedStatus(response.status_code, response.content)
+    else:
+        return None
+
+
+def _build_response(
+    *, client: AuthenticatedClient | Client, response: httpx.Response
+) -> Response[Error | ParamsValidation]:
+    return Response(
+        status_code=HTTPStatus(response.status_code),
+        content=response.content,
+        headers=response.headers,
+        parsed=_parse_response(client=client, response=response),
+    )
+
+
+def sync_detailed(
+    *,
+    client: AuthenticatedClient | Client,
+    body: GameParameters | Unset = UNSET,
+) -> Response[Error | ParamsValidation]:
+    """Check game parameters for validity without creating a game. Parameters that are not set take their default
+    values, as when creating a game
+
+    Args:
+        body (GameParameters | Unset): Full or partial game parameters. Fields that are not set take
+            their default values
+
+    Raises:
+        errors.UnexpectedStatus: If the server returns an undocumented status code and Client.raise_on_unexpected_status is True.
+        httpx.TimeoutException: If the request takes longer than Client.timeout.
+
+    Returns:
+        Response[Error | ParamsValidation]
+    """
+
+    kwargs = _get_kwargs(
+        body=body,
+    )
+
+    response = client.get_httpx_client().request(
+        **kwargs,
+    )
+
+    return _build_response(client=client, response=response)
+
+
+def sync(
+    *,
+    client: AuthenticatedClient | Client,
+    body: GameParameters | Unset = UNSET,
+) -> Error | ParamsValidation | None:
+    """Check game parameters for validity without creating a game. Parameters that are not set take their default
+    values, as when creating a game
+
+    Args:
+        body (GameParameters | Unset): Full or partial game parameters. Fields that are not set take
+            their default values
+
+    Raises:
+        errors.UnexpectedStatus: If the server returns an undocumented status code and Client.raise_on_unexpected_status is True.
+        httpx.TimeoutException: If the request takes longer than Client.timeout.
+
+    Returns:
+        Error | ParamsValidation
+    """
+
+    return sync_detailed(
+        client=client,
+        body=body,
+    ).parsed
+
+
+async def asyncio_detailed(
+    *,
+    client: AuthenticatedClient | Client,
+    body: GameParameters | Unset = UNSET,
+) -> Response[Error | ParamsValidation]:
+    """Check game parameters for validity without creating a game. Parameters that are not set take their default
+    values, as when creating a game
+
+    Args:
+        body (GameParameters | Unset): Full or partial game parameters. Fields that are not set take
+            their default values
+
+    Raises:
+        errors.UnexpectedStatus: If the server returns an undocumented status code and Client.raise_on_unexpected_status is True.
+        httpx.TimeoutException: If the request takes longer than Client.timeout.
+
+    Returns:
+        Response[Error | ParamsValidation]
+    """
+
+    kwargs = _get_kwargs(
+        body=body,
+    )
+
+    response = await client.get_async_httpx_client().request(**kwargs)
+
+    return _build_response(client=client, response=response)
+
+
+async def asyncio(
+    *,
+    client: AuthenticatedClient | Client,
+    body: GameParameters | Unset = UNSET,
+) -> Error | ParamsValidation | None:
+    """Check game parameters for validity without creating a game. Parameters that are not set take their default
+    values, as when creating a game
+
+    Args:
+        body (GameParameters | Unset): Full or partial game parameters. Fields that are not set take
+            their default values
+
+    Raises:
+        errors.UnexpectedStatus: If the server returns an undocumented status code and Client.raise_on_unexpected_status is True.
+        httpx.TimeoutException: If the request takes longer than Client.timeout.
+
+    Returns:
+        Error | ParamsValidation
+    """
+
+    return (
+        await asyncio_detailed(
+            client=client,
+            )
+    ).parsed
@@ -1,31 +1,49 @@
 """Contains all the data models used in inputs/outputs"""
 
+from .action_result import ActionResult
+from .action_result_status import ActionResultStatus
 from .asset_mix import AssetMix
+from .batch_update import BatchUpdate
 from .error import Error
 from .game import Game
 from .game_id_list import GameIDList
 from .game_last_round_snapshot import GameLastRoundSnapshot
+from .game_parameters import GameParameters
+from .game_parameters_starting_fossil_assets_per_player import GameParametersStartingFossilAssetsPerPlayer
 from .game_reason import GameReason
 from .game_status import GameStatus
 from .game_update import GameUpdate
+from .game_visibility import GameVisibility
+from .params_validation import ParamsValidation
 from .player import Player
 from .player_action import PlayerAction
 from .player_action_asset_type import PlayerActionAssetType
 from .player_action_type import PlayerActionType
 from .player_status import PlayerStatus
+from .post_new_visibility import PostNewVisibility
+from .rewards import Rewards
 
 __all__ = (
+    "ActionResult",
+    "ActionResultStatus",
     "AssetMix",
+    "BatchUpdate",
     "Error",
     "Game",
     "GameIDList",
     "GameLastRoundSnapshot",
+    "GameParameters",
+    "GameParametersStartingFossilAssetsPerPlayer",
     "GameReason",
     "GameStatus",
     "GameUpdate",
+    "GameVisibility",
+    "ParamsValidation",
     "Player",
     "PlayerAction",
     "PlayerActionAssetType",
     "PlayerActionType",
     "PlayerStatus",
+    "PostNewVisibility",
+    "Rewards",
 )
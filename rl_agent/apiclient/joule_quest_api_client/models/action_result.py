@@ -0,0 +1,99 @@
+from __future__ import annotations
+
+from collections.abc import Mapping
+from typing import TYPE_CHECKING, Any, TypeVar
+
+from attrs import define as _attrs_define
+
+from ..models.action_result_status import ActionResultStatus
+from ..types import UNSET, Unset
+
+if TYPE_CHECKING:
+    from ..models.error import Error
+    from ..models.player_action import PlayerAction
+    from ..models.rewards import Rewards
+
+
+T = TypeVar("T", bound="ActionResult")
+
+
+@_attrs_define
+class ActionResult:
+    """The outcome of one action of a batch. Rewards are only set for applied actions, and Error only for rejected
+    actions
+
+    Attributes:
+        action (PlayerAction):
+        status (ActionResultStatus): Applied, Rejected if the action was invalid, or Skipped if it was not attempted
+            because an earlier action was rejected or ended the build phase
+        rewards (Rewards | Unset): Each player's reward for the last action, indexed by player, under each reward
+            scheme. The reward covers any operate phase the action triggered.
+        error (Error | Unset):
+    """
+
+    action: PlayerAction
+    status: ActionResultStatus
+    rewards: Rewards | Unset = UNSET
+    error: Error | Unset = UNSET
+
+    def to_dict(self) -> dict[str, Any]:
+        action = self.action.to_dict()
+
+        status = self.status.value
+
+        rewards: dict[str, Any] | Unset = UNSET
+        if not isinstance(self.rewards, Unset):
+            rewards = self.rewards.to_dict()
+
+        error: dict[str, Any] | Unset = UNSET
+        if not isinstance(self.error, Unset):
+            error = self.error.to_dict()
+
+        field_dict: dict[str, Any] = {}
+
+        field_dict.update(
+            {
+                "Action": action,
+                "Status": status,
+            }
+        )
+        if rewards is not UNSET:
+            field_dict["Rewards"] = rewards
+        if error is not UNSET:
+            field_dict["Error"] = error
+
+        return field_dict
+
+    @classmethod
+    def from_dict(cls: type[T], src_dict: Mapping[str, Any]) -> T:
+        from ..models.error import Error
+        from ..models.player_action import PlayerAction
+        from ..models.rewards import Rewards
+
+        d = dict(src_dict)
+        action = PlayerAction.from_dict(d.pop("Action"))
+
+        status = ActionResultStatus(d.pop("Status"))
+
+        _rewards = d.pop("Rewards", UNSET)
+        rewards: Rewards | Unset
+        if isinstance(_rewards, Unset):
+            rewards = UNSET
+        else:
+            rewards = Rewards.from_dict(_rewards)
+
+        _error = d.pop("Error", UNSET)
+        error: Error | Unset
+        if isinstance(_error, Unset):
+            error = UNSET
+        else:
+            error = Error.from_dict(_error)
+
+        action_result = cls(
+            action=action,
+            status=status,
+            rewards=rewards,
+            error=error,
+        )
+
+        return action_result
@@ -0,0 +1,10 @@
+from enum import Enum
+
+
+class ActionResultStatus(str, Enum):
+    APPLIED = "Applied"
+    REJECTED = "Rejected"
+    SKIPPED = "Skipped"
+
+    def __str__(self) -> str:
+        return str(self.value)
@@ -0,0 +1,172 @@
+from __future__ import annotations
+
+from collections.abc import Mapping
+from typing import TYPE_CHECKING, Any, TypeVar, cast
+
+from attrs import define as _attrs_define
+from attrs import field as _attrs_field
+
+from ..types import UNSET, Unset
+
+if TYPE_CHECKING:
+    from ..models.action_result import ActionResult
+    from ..models.game import Game
+    from ..models.player_action import PlayerAction
+    from ..models.rewards import Rewards
+
+
+T = TypeVar("T", bound="BatchUpdate")
+
+
+@_attrs_define
+class BatchUpdate:
+    """
+    Attributes:
+        id (str): Internal game ID
+        seed (int): Seed for the game's random number generator. Creating a game with the same seed, parameters and
+            actions replays it exactly
+        possible_actions (list[PlayerAction] | None): The set of actions that may be sent in the next request to
+            /g/{GameID}/action. With a seat token, only that seat's actions
+        game (Game):
+        results (list[ActionResult]): The result of each action, in the order they were sent
+        rewards (Rewards | Unset): Each player's reward for the last action, indexed by player, under each reward
+            scheme. The reward covers any operate phase the action triggered.
+        seat_tokens (list[str] | Unset): Bearer tokens for each seat, indexed by PlayerIndex. Only set when the game is
+            created with seatTokens=true
+    """
+
+    id: str
+    seed: int
+    possible_actions: list[PlayerAction] | None
+    game: Game
+    results: list[ActionResult]
+    rewards: Rewards | Unset = UNSET
+    seat_tokens: list[str] | Unset = UNSET
+    additional_properties: dict[str, Any] = _attrs_field(init=False, factory=dict)
+
+    def to_dict(self) -> dict[str, Any]:
+        id = self.id
+
+        seed = self.seed
+
+        possible_actions: list[dict[str, Any]] | None
+        if isinstance(self.possible_actions, list):
+            possible_actions = []
+            for possible_actions_type_0_item_data in self.possible_actions:
+                possible_actions_type_0_item = possible_actions_type_0_item_data.to_dict()
+                possible_actions.append(possible_actions_type_0_item)
+
+        else:
+            possible_actions = self.possible_actions
+
+        game = self.game.to_dict()
+
+        results = []
+        for results_item_data in self.results:
+            results_item = results_item_data.to_dict()
+            results.append(results_item)
+
+        rewards: dict[str, Any] | Unset = UNSET
+        if not isinstance(self.rewards, Unset):
+            rewards = self.rewards.to_dict()
+
+        seat_tokens: list[str] | Unset = UNSET
+        if not isinstance(self.seat_tokens, Unset):
+            seat_tokens = self.seat_tokens
+
+        field_dict: dict[str, Any] = {}
+        field_dict.update(self.additional_properties)
+        field_dict.update(
+            {
+                "ID": id,
+                "Seed": seed,
+                "PossibleActions": possible_actions,
+                "Game": game,
+                "Results": results,
+            }
+        )
+        if rewards is not UNSET:
+            field_dict["Rewards"] = rewards
+        if seat_tokens is not UNSET:
+            field_dict["SeatTokens"] = seat_tokens
+
+        return field_dict
+
+    @classmethod
+    def from_dict(cls: type[T], src_dict: Mapping[str, Any]) -> T:
+        from ..models.action_result import ActionResult
+        from ..models.game import Game
+        from ..models.player_action import PlayerAction
+        from ..models.rewards import Rewards
+
+        d = dict(src_dict)
+        id = d.pop("ID")
+
+        seed = d.pop("Seed")
+
+        def _parse_possible_actions(data: object) -> list[PlayerAction] | None:
+            if data is None:
+                return data
+            try:
+                if not isinstance(data, list):
+                    raise TypeError()
+                possible_actions_type_0 = []
+                _possible_actions_type_0 = data
+                for possible_actions_type_0_item_data in _possible_actions_type_0:
+                    possible_actions_type_0_item = PlayerAction.from_dict(possible_actions_type_0_item_data)
+
+                    possible_actions_type_0.append(possible_actions_type_0_item)
+
+                return possible_actions_type_0
+            except (TypeError, ValueError, AttributeError, KeyError):
+                pass
+            return cast(list[PlayerAction] | None, data)
+
+        possible_actions = _parse_possible_actions(d.pop("PossibleActions"))
+
+        game = Game.from_dict(d.pop("Game"))
+
+        results = []
+        _results = d.pop("Results")
+        for results_item_data in _results:
+            results_item = ActionResult.from_dict(results_item_data)
+
+            results.append(results_item)
+
+        _rewards = d.pop("Rewards", UNSET)
+        rewards: Rewards | Unset
+        if isinstance(_rewards, Unset):
+            rewards = UNSET
+        else:
+            rewards = Rewards.from_dict(_rewards)
+
+        seat_tokens = cast(list[str], d.pop("SeatTokens", UNSET))
+
+        batch_update = cls(
+            id=id,
+            seed=seed,
+            possible_actions=possible_actions,
+            game=game,
+            results=results,
+            rewards=rewards,
+            seat_tokens=seat_tokens,
+        )
+
+        batch_update.additional_properties = d
+        return batch_update
+
+    @property
+    def additional_keys(self) -> list[str]:
+        return list(self.additional_properties.keys())
+
+    def __getitem__(self, key: str) -> Any:
+        return self.additional_properties[key]
+
+    def __setitem__(self, key: str, value: Any) -> None:
+        self.additional_properties[key] = value
+
+    def __delitem__(self, key: str) -> None:
+        del self.additional_properties[key]
+
+    def __contains__(self, key: str) -> bool:
+        return key in self.additional_properties
@@ -1,10 +1,16 @@
 from __future__ import annotations
 
 from collections.abc import Mapping
-from typing import Any, TypeVar
+from typing import TYPE_CHECKING, Any, TypeVar
 
 from attrs import define as _attrs_define
 
+from ..types import UNSET, Unset
+
+if TYPE_CHECKING:
+    from ..models.player_action import PlayerAction
+
+
 T = TypeVar("T", bound="Error")
 
 
@@ -12,31 +18,80 @@ T = TypeVar("T", bound="Error")
 class Error:
     """
     Attributes:
+        code (int): Machine-readable error code, using the same values as the WASM module's ErrCode: 1 invalid player
+            count, 2 invalid action, 3 unknown (e.g. no such game, or a conflicting request; see the HTTP status), 4
+            invalid parameter
         error (str): The error message
+        action (PlayerAction | Unset): The rejected action, for invalid actions
+        legal_actions (list[PlayerAction] | Unset): The actions that were legal when the action was rejected, for
+            invalid actions
     """
 
+    code: int
     error: str
+    action: PlayerAction | Unset = UNSET
+    legal_actions: list[PlayerAction] | Unset = UNSET
 
     def to_dict(self) -> dict[str, Any]:
+        code = self.code
+
         error = self.error
 
-        field_dict: dict[str, Any] = {}
+        action: dict[str, Any] | Unset = UNSET
+        if not isinstance(self.action, Unset):
+            action = self.action.to_dict()
+
+        legal_actions: list[dict[str, Any]] | Unset = UNSET
+        if not isinstance(self.legal_actions, Unset):
+            legal_actions = []
+            for legal_actions_item_data in self.legal_actions:
+                legal_actions_item = legal_actions_item_data.to_dict()
+                legal_actions.append(legal_actions_item)
 
+        field_dict: dict[str, Any] = {}
         field_dict.update(
             {
+                "code": code,
                 "error": error,
             }
         )
+        if action is not UNSET:
+            field_dict["action"] = action
+        if legal_actions is not UNSET:
+            field_dict["legal_actions"] = legal_actions
 
         return field_dict
 
     @classmethod
     def from_dict(cls: type[T], src_dict: Mapping[str, Any]) -> T:
+        from ..models.player_action import PlayerAction
+
         d = dict(src_dict)
+        code = d.pop("code")
+
         error = d.pop("error")
 
+        _action = d.pop("action", UNSET)
+        action: PlayerAction | Unset
+        if isinstance(_action, Unset):
+            action = UNSET
+        else:
+            action = PlayerAction.from_dict(_action)
+
+        _legal_actions = d.pop("legal_actions", UNSET)
+        legal_actions: list[PlayerAction] | Unset = UNSET
+        if _legal_actions is not UNSET:
+            legal_actions = []
+            for legal_actions_item_data in _legal_actions:
+                legal_actions_item = PlayerAction.from_dict(legal_actions_item_data)
+
+                legal_actions.append(legal_actions_item)
+
         error = cls(
+            code=code,
             error=error,
+            action=action,
+            legal_actions=legal_actions,
         )
 
         return error
@@ -7,6 +7,7 @@ from attrs import define as _attrs_define
 
 from ..models.game_reason import GameReason
 from ..models.game_status import GameStatus
+from ..models.game_visibility import GameVisibility
 
 if TYPE_CHECKING:
     from ..models.asset_mix import AssetMix
@@ -28,6 +29,7 @@ class Game:
         last_round_snapshot (GameLastRoundSnapshot): Summary statistics from the last round of the game
         players (list[Player]):
         takeover_pool (AssetMix):
+        visibility (GameVisibility): Policy applied to Players, as set when the game was created
     """
 
     status: GameStatus
@@ -36,6 +38,7 @@ class Game:
     last_round_snapshot: GameLastRoundSnapshot
     players: list[Player]
     takeover_pool: AssetMix
+    visibility: GameVisibility
     reason: GameReason = GameReason.NONE
 
     def to_dict(self) -> dict[str, Any]:
@@ -56,6 +59,8 @@ class Game:
 
         takeover_pool = self.takeover_pool.to_dict()
 
+        visibility = self.visibility.value
+
         field_dict: dict[str, Any] = {}
 
         field_dict.update(
@@ -67,6 +72,7 @@ class Game:
                 "LastRoundSnapshot": last_round_snapshot,
                 "Players": players,
                 "TakeoverPool": takeover_pool,
+                "Visibility": visibility,
             }
         )
 
@@ -98,6 +104,8 @@ class Game:
 
         takeover_pool = AssetMix.from_dict(d.pop("TakeoverPool"))
 
+        visibility = GameVisibility(d.pop("Visibility"))
+
         game = cls(
             status=status,
             reason=reason,
@@ -106,6 +114,7 @@ class Game:
             last_round_snapshot=last_round_snapshot,
             players=players,
             takeover_pool=takeover_pool,
+            visibility=visibility,
         )
 
         return game
@@ -0,0 +1,283 @@
+from __future__ import annotations
+
+from collections.abc import Mapping
+from typing import TYPE_CHECKING, Any, TypeVar, cast
+
+from attrs import define as _attrs_define
+
+from ..types import UNSET, Unset
+
+if TYPE_CHECKING:
+    from ..models.game_parameters_starting_fossil_assets_per_player import GameParametersStartingFossilAssetsPerPlayer
+
+
+T = TypeVar("T", bound="GameParameters")
+
+
+@_attrs_define
+class GameParameters:
+    """
+    Full or partial game parameters. Fields that are not set take their default values
+
+    Attributes:
+        capacity_rule (int | Unset): 0: PaymentPerAsset, 1: NoCapacityMarket, 2: SharedCapacityPaymentPool
+        carbon_tax_rule (int | Unset): 0: NoCarbonTax, 1: ApplyCarbonTax
+        win_condition_rule (int | Unset): 0: LastFossilLoses, 1: RenewablePenetrationThreshold
+        generation_constraint_rule (int | Unset): 0: Minimum, 1: MaxDecrease
+        takeover_rule (int | Unset): 0: ForcedTakeover, 1: VirtualOwner
+        initial_cash (int | Unset):
+        starting_fossil_assets_per_player (GameParametersStartingFossilAssetsPerPlayer | Unset): Number of fossil assets each player starts
+            with, keyed by number of players. Merged with the default map
+        battery_build_cost (int | Unset):
+        battery_scrap_cost (int | Unset):
+        renewable_build_cost (int | Unset):
+        renewable_scrap_cost (int | Unset):
+        fossil_build_cost (int | Unset):
+        fossil_scrap_cost (int | Unset):
+        emissions_cap (int | Unset):
+        generation_constraint (int | Unset):
+        carbon_tax_threshold (int | Unset):
+        carbon_tax_cost (int | Unset):
+        renewable_penetration (int | Unset): Percentage of renewable generation assets needed to win with the
+            RenewablePenetrationThreshold win condition
+        renewable_pn_l (list[int] | Unset): Profit or loss per asset, indexed by PriceVolatility (Low,
+            Medium, High, Extreme)
+        battery_arbitrage_pn_l (list[int] | Unset): Profit or loss per asset, indexed by PriceVolatility (Low,
+            Medium, High, Extreme)
+        battery_capacity_pn_l (list[int] | Unset): Profit or loss per asset, indexed by PriceVolatility (Low,
+            Medium, High, Extreme)
+        fossil_wholesale_pn_l (list[int] | Unset): Profit or loss per asset, indexed by PriceVolatility (Low,
+            Medium, High, Extreme)
+        fossil_capacity_pn_l (list[int] | Unset): Profit or loss per asset, indexed by PriceVolatility (Low,
+            Medium, High, Extreme)
+        capacity_pool_pn_l (list[int] | Unset): Profit or loss per asset, indexed by PriceVolatility (Low,
+            Medium, High, Extreme)
+    """
+
+    capacity_rule: int | Unset = UNSET
+    carbon_tax_rule: int | Unset = UNSET
+    win_condition_rule: int | Unset = UNSET
+    generation_constraint_rule: int | Unset = UNSET
+    takeover_rule: int | Unset = UNSET
+    initial_cash: int | Unset = UNSET
+    starting_fossil_assets_per_player: GameParametersStartingFossilAssetsPerPlayer | Unset = UNSET
+    battery_build_cost: int | Unset = UNSET
+    battery_scrap_cost: int | Unset = UNSET
+    renewable_build_cost: int | Unset = UNSET
+    renewable_scrap_cost: int | Unset = UNSET
+    fossil_build_cost: int | Unset = UNSET
+    fossil_scrap_cost: int | Unset = UNSET
+    emissions_cap: int | Unset = UNSET
+    generation_constraint: int | Unset = UNSET
+    carbon_tax_threshold: int | Unset = UNSET
+    carbon_tax_cost: int | Unset = UNSET
+    renewable_penetration: int | Unset = UNSET
+    renewable_pn_l: list[int] | Unset = UNSET
+    battery_arbitrage_pn_l: list[int] | Unset = UNSET
+    battery_capacity_pn_l: list[int] | Unset = UNSET
+    fossil_wholesale_pn_l: list[int] | Unset = UNSET
+    fossil_capacity_pn_l: list[int] | Unset = UNSET
+    capacity_pool_pn_l: list[int] | Unset = UNSET
+
+    def to_dict(self) -> dict[str, Any]:
+        capacity_rule = self.capacity_rule
+
+        carbon_tax_rule = self.carbon_tax_rule
+
+        win_condition_rule = self.win_condition_rule
+
+        generation_constraint_rule = self.generation_constraint_rule
+
+        takeover_rule = self.takeover_rule
+
+        initial_cash = self.initial_cash
+
+        starting_fossil_assets_per_player: dict[str, Any] | Unset = UNSET
+        if not isinstance(self.starting_fossil_assets_per_player, Unset):
+            starting_fossil_assets_per_player = self.starting_fossil_assets_per_player.to_dict()
+
+        battery_build_cost = self.battery_build_cost
+
+        battery_scrap_cost = self.battery_scrap_cost
+
+        renewable_build_cost = self.renewable_build_cost
+
+        renewable_scrap_cost = self.renewable_scrap_cost
+
+        fossil_build_cost = self.fossil_build_cost
+
+        fossil_scrap_cost = self.fossil_scrap_cost
+
+        emissions_cap = self.emissions_cap
+
+        generation_constraint = self.generation_constraint
+
+        carbon_tax_threshold = self.carbon_tax_threshold
+
+        carbon_tax_cost = self.carbon_tax_cost
+
+        renewable_penetration = self.renewable_penetration
+
+        renewable_pn_l: list[int] | Unset = UNSET
+        if not isinstance(self.renewable_pn_l, Unset):
+            renewable_pn_l = self.renewable_pn_l
+
+        battery_arbitrage_pn_l: list[int] | Unset = UNSET
+        if not isinstance(self.battery_arbitrage_pn_l, Unset):
+            battery_arbitrage_pn_l = self.battery_arbitrage_pn_l
+
+        battery_capacity_pn_l: list[int] | Unset = UNSET
+        if not isinstance(self.battery_capacity_pn_l, Unset):
+            battery_capacity_pn_l = self.battery_capacity_pn_l
+
+        fossil_wholesale_pn_l: list[int] | Unset = UNSET
+        if not isinstance(self.fossil_wholesale_pn_l, Unset):
+            fossil_wholesale_pn_l = self.fossil_wholesale_pn_l
+
+        fossil_capacity_pn_l: list[int] | Unset = UNSET
+        if not isinstance(self.fossil_capacity_pn_l, Unset):
+            fossil_capacity_pn_l = self.fossil_capacity_pn_l
+
+        capacity_pool_pn_l: list[int] | Unset = UNSET
+        if not isinstance(self.capacity_pool_pn_l, Unset):
+            capacity_pool_pn_l = self.capacity_pool_pn_l
+
+        field_dict: dict[str, Any] = {}
+        field_dict.update({})
+        if capacity_rule is not UNSET:
+            field_dict["CapacityRule"] = capacity_rule
+        if carbon_tax_rule is not UNSET:
+            field_dict["CarbonTaxRule"] = carbon_tax_rule
+        if win_condition_rule is not UNSET:
+            field_dict["WinConditionRule"] = win_condition_rule
+        if generation_constraint_rule is not UNSET:
+            field_dict["GenerationConstraintRule"] = generation_constraint_rule
+        if takeover_rule is not UNSET:
+            field_dict["TakeoverRule"] = takeover_rule
+        if initial_cash is not UNSET:
+            field_dict["InitialCash"] = initial_cash
+        if starting_fossil_assets_per_player is not UNSET:
+            field_dict["StartingFossilAssetsPerPlayer"] = starting_fossil_assets_per_player
+        if battery_build_cost is not UNSET:
+            field_dict["BatteryBuildCost"] = battery_build_cost
+        if battery_scrap_cost is not UNSET:
+            field_dict["BatteryScrapCost"] = battery_scrap_cost
+        if renewable_build_cost is not UNSET:
+            field_dict["RenewableBuildCost"] = renewable_build_cost
+        if renewable_scrap_cost is not UNSET:
+            field_dict["RenewableScrapCost"] = renewable_scrap_cost
+        if fossil_build_cost is not UNSET:
+            field_dict["FossilBuildCost"] = fossil_build_cost
+        if fossil_scrap_cost is not UNSET:
+            field_dict["FossilScrapCost"] = fossil_scrap_cost
+        if emissions_cap is not UNSET:
+            field_dict["EmissionsCap"] = emissions_cap
+        if generation_constraint is not UNSET:
+            field_dict["GenerationConstraint"] = generation_constraint
+        if carbon_tax_threshold is not UNSET:
+            field_dict["CarbonTaxThreshold"] = carbon_tax_threshold
+        if carbon_tax_cost is not UNSET:
+            field_dict["CarbonTaxCost"] = carbon_tax_cost
+        if renewable_penetration is not UNSET:
+            field_dict["RenewablePenetration"] = renewable_penetration
+        if renewable_pn_l is not UNSET:
+            field_dict["RenewablePnL"] = renewable_pn_l
+        if battery_arbitrage_pn_l is not UNSET:
+            field_dict["BatteryArbitragePnL"] = battery_arbitrage_pn_l
+        if battery_capacity_pn_l is not UNSET:
+            field_dict["BatteryCapacityPnL"] = battery_capacity_pn_l
+        if fossil_wholesale_pn_l is not UNSET:
+            field_dict["FossilWholesalePnL"] = fossil_wholesale_pn_l
+        if fossil_capacity_pn_l is not UNSET:
+            field_dict["FossilCapacityPnL"] = fossil_capacity_pn_l
+        if capacity_pool_pn_l is not UNSET:
+            field_dict["CapacityPoolPnL"] = capacity_pool_pn_l
+
+        return field_dict
+
+    @classmethod
+    def from_dict(cls: type[T], src_dict: Mapping[str, Any]) -> T:
+        from ..models.game_parameters_starting_fossil_assets_per_player import GameParametersStartingFossilAssetsPerPlayer
+
+        d = dict(src_dict)
+        capacity_rule = d.pop("CapacityRule", UNSET)
+
+        carbon_tax_rule = d.pop("CarbonTaxRule", UNSET)
+
+        win_condition_rule = d.pop("WinConditionRule", UNSET)
+
+        generation_constraint_rule = d.pop("GenerationConstraintRule", UNSET)
+
+        takeover_rule = d.pop("TakeoverRule", UNSET)
+
+        initial_cash = d.pop("InitialCash", UNSET)
+
+        _starting_fossil_assets_per_player = d.pop("StartingFossilAssetsPerPlayer", UNSET)
+        starting_fossil_assets_per_player: GameParametersStartingFossilAssetsPerPlayer | Unset
+        if isinstance(_starting_fossil_assets_per_player, Unset):
+            starting_fossil_assets_per_player = UNSET
+        else:
+            starting_fossil_assets_per_player = GameParametersStartingFossilAssetsPerPlayer.from_dict(_starting_fossil_assets_per_player)
+
+        battery_build_cost = d.pop("BatteryBuildCost", UNSET)
+
+        battery_scrap_cost = d.pop("BatteryScrapCost", UNSET)
+
+        renewable_build_cost = d.pop("RenewableBuildCost", UNSET)
+
+        renewable_scrap_cost = d.pop("RenewableScrapCost", UNSET)
+
+        fossil_build_cost = d.pop("FossilBuildCost", UNSET)
+
+        fossil_scrap_cost = d.pop("FossilScrapCost", UNSET)
+
+        emissions_cap = d.pop("EmissionsCap", UNSET)
+
+        generation_constraint = d.pop("GenerationConstraint", UNSET)
+
+        carbon_tax_threshold = d.pop("CarbonTaxThreshold", UNSET)
+
+        carbon_tax_cost = d.pop("CarbonTaxCost", UNSET)
+
+        renewable_penetration = d.pop("RenewablePenetration", UNSET)
+
+        renewable_pn_l = cast(list[int], d.pop("RenewablePnL", UNSET))
+
+        battery_arbitrage_pn_l = cast(list[int], d.pop("BatteryArbitragePnL", UNSET))
+
+        battery_capacity_pn_l = cast(list[int], d.pop("BatteryCapacityPnL", UNSET))
+
+        fossil_wholesale_pn_l = cast(list[int], d.pop("FossilWholesalePnL", UNSET))
+
+        fossil_capacity_pn_l = cast(list[int], d.pop("FossilCapacityPnL", UNSET))
+
+        capacity_pool_pn_l = cast(list[int], d.pop("CapacityPoolPnL", UNSET))
+
+        game_parameters = cls(
+            capacity_rule=capacity_rule,
+            carbon_tax_rule=carbon_tax_rule,
+            win_condition_rule=win_condition_rule,
+            generation_constraint_rule=generation_constraint_rule,
+            takeover_rule=takeover_rule,
+            initial_cash=initial_cash,
+            starting_fossil_assets_per_player=starting_fossil_assets_per_player,
+            battery_build_cost=battery_build_cost,
+            battery_scrap_cost=battery_scrap_cost,
+            renewable_build_cost=renewable_build_cost,
+            renewable_scrap_cost=renewable_scrap_cost,
+            fossil_build_cost=fossil_build_cost,
+            fossil_scrap_cost=fossil_scrap_cost,
+            emissions_cap=emissions_cap,
+            generation_constraint=generation_constraint,
+            carbon_tax_threshold=carbon_tax_threshold,
+            carbon_tax_cost=carbon_tax_cost,
+            renewable_penetration=renewable_penetration,
+            renewable_pn_l=renewable_pn_l,
+            battery_arbitrage_pn_l=battery_arbitrage_pn_l,
+            battery_capacity_pn_l=battery_capacity_pn_l,
+            fossil_wholesale_pn_l=fossil_wholesale_pn_l,
+            fossil_capacity_pn_l=fossil_capacity_pn_l,
+            capacity_pool_pn_l=capacity_pool_pn_l,
+        )
+
+        return game_parameters
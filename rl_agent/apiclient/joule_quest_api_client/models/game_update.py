@@ -6,9 +6,12 @@ from typing import TYPE_CHECKING, Any, TypeVar, cast
 from attrs import define as _attrs_define
 from attrs import field as _attrs_field
 
+from ..types import UNSET, Unset
+
 if TYPE_CHECKING:
     from ..models.game import Game
     from ..models.player_action import PlayerAction
+    from ..models.rewards import Rewards
 
 
 T = TypeVar("T", bound="GameUpdate")
@@ -19,19 +22,30 @@ class GameUpdate:
     """
     Attributes:
         id (str): Internal game ID
+        seed (int): Seed for the game's random number generator. Creating a game with the same seed, parameters and
+            actions replays it exactly
         possible_actions (list[PlayerAction] | None): The set of actions that may be sent in the next request to
-            /g/{GameID}/action
+            /g/{GameID}/action. With a seat token, only that seat's actions
         game (Game):
+        rewards (Rewards | Unset): Each player's reward for the last action, indexed by player, under each reward
+            scheme. The reward covers any operate phase the action triggered.
+        seat_tokens (list[str] | Unset): Bearer tokens for each seat, indexed by PlayerIndex. Only set when the game is
+            created with seatTokens=true
     """
 
     id: str
+    seed: int
     possible_actions: list[PlayerAction] | None
     game: Game
+    rewards: Rewards | Unset = UNSET
+    seat_tokens: list[str] | Unset = UNSET
     additional_properties: dict[str, Any] = _attrs_field(init=False, factory=dict)
 
     def to_dict(self) -> dict[str, Any]:
         id = self.id
 
+        seed = self.seed
+
         possible_actions: list[dict[str, Any]] | None
         if isinstance(self.possible_actions, list):
             possible_actions = []
@@ -44,15 +58,28 @@ class GameUpdate:
 
         game = self.game.to_dict()
 
+        rewards: dict[str, Any] | Unset = UNSET
+        if not isinstance(self.rewards, Unset):
+            rewards = self.rewards.to_dict()
+
+        seat_tokens: list[str] | Unset = UNSET
+        if not isinstance(self.seat_tokens, Unset):
+            seat_tokens = self.seat_tokens
+
         field_dict: dict[str, Any] = {}
         field_dict.update(self.additional_properties)
         field_dict.update(
             {
                 "ID": id,
+                "Seed": seed,
                 "PossibleActions": possible_actions,
                 "Game": game,
             }
         )
+        if rewards is not UNSET:
+            field_dict["Rewards"] = rewards
+        if seat_tokens is not UNSET:
+            field_dict["SeatTokens"] = seat_tokens
 
         return field_dict
 
@@ -60,10 +87,13 @@ class GameUpdate:
     def from_dict(cls: type[T], src_dict: Mapping[str, Any]) -> T:
         from ..models.game import Game
         from ..models.player_action import PlayerAction
+        from ..models.rewards import Rewards
 
         d = dict(src_dict)
         id = d.pop("ID")
 
+        seed = d.pop("Seed")
+
         def _parse_possible_actions(data: object) -> list[PlayerAction] | None:
             if data is None:
                 return data
@@ -86,10 +116,22 @@ class GameUpdate:
 
         game = Game.from_dict(d.pop("Game"))
 
+        _rewards = d.pop("Rewards", UNSET)
+        rewards: Rewards | Unset
+        if isinstance(_rewards, Unset):
+            rewards = UNSET
+        else:
+            rewards = Rewards.from_dict(_rewards)
+
+        seat_tokens = cast(list[str], d.pop("SeatTokens", UNSET))
+
         game_update = cls(
             id=id,
+            seed=seed,
             possible_actions=possible_actions,
             game=game,
+            rewards=rewards,
+            seat_tokens=seat_tokens,
         )
 
         game_update.additional_properties = d
@@ -0,0 +1,10 @@
+from enum import Enum
+
+
+class GameVisibility(str, Enum):
+    BUCKETEDMIX = "BucketedMix"
+    FULL = "Full"
+    HIDDENMONEY = "HiddenMoney"
+
+    def __str__(self) -> str:
+        return str(self.value)
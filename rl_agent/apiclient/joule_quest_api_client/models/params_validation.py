@@ -0,0 +1,50 @@
+from __future__ import annotations
+
+from collections.abc import Mapping
+from typing import Any, TypeVar, cast
+
+from attrs import define as _attrs_define
+
+T = TypeVar("T", bound="ParamsValidation")
+
+
+@_attrs_define
+class ParamsValidation:
+    """
+    Attributes:
+        valid (bool): Whether the parameters can be used to create a game
+        errors (list[str]): Each reason the parameters are invalid, as a separate entry. Empty if they are valid
+    """
+
+    valid: bool
+    errors: list[str]
+
+    def to_dict(self) -> dict[str, Any]:
+        valid = self.valid
+
+        errors = self.errors
+
+        field_dict: dict[str, Any] = {}
+
+        field_dict.update(
+            {
+                "Valid": valid,
+                "Errors": errors,
+            }
+        )
+
+        return field_dict
+
+    @classmethod
+    def from_dict(cls: type[T], src_dict: Mapping[str, Any]) -> T:
+        d = dict(src_dict)
+        valid = d.pop("Valid")
+
+        errors = cast(list[str], d.pop("Errors"))
+
+        params_validation = cls(
+            valid=valid,
+            errors=errors,
+        )
+
+        return params_validation
@@ -19,7 +19,7 @@ class Player:
     """
     Attributes:
         status (PlayerStatus):
-        money (int):
+        money (int): Reads as 0 when hidden by the game's visibility policy
         assets (AssetMix):
     """
 
@@ -0,0 +1,83 @@
+from __future__ import annotations
+
+from collections.abc import Mapping
+from typing import Any, TypeVar, cast
+
+from attrs import define as _attrs_define
+from attrs import field as _attrs_field
+
+T = TypeVar("T", bound="Rewards")
+
+
+@_attrs_define
+class Rewards:
+    """Each player's reward for the last action, indexed by player, under each reward scheme. The reward covers any
+    operate phase the action triggered.
+
+    Attributes:
+        terminal (list[int]): +1 for each surviving player when the game is won, -1 for a player who loses individually
+            or when the game is lost
+        money_delta (list[int]): The change in each player's money. Opponents' changes read as 0 when the visibility
+            policy hides their money
+        shaped (list[int]): The terminal reward multiplied by 100, plus the change in renewable penetration of the last
+            round snapshot, minus the carbon emissions added. Only players who were active before the action are
+            rewarded
+    """
+
+    terminal: list[int]
+    money_delta: list[int]
+    shaped: list[int]
+    additional_properties: dict[str, Any] = _attrs_field(init=False, factory=dict)
+
+    def to_dict(self) -> dict[str, Any]:
+        terminal = self.terminal
+
+        money_delta = self.money_delta
+
+        shaped = self.shaped
+
+        field_dict: dict[str, Any] = {}
+        field_dict.update(self.additional_properties)
+        field_dict.update(
+            {
+                "Terminal": terminal,
+                "MoneyDelta": money_delta,
+                "Shaped": shaped,
+            }
+        )
+
+        return field_dict
+
+    @classmethod
+    def from_dict(cls: type[T], src_dict: Mapping[str, Any]) -> T:
+        d = dict(src_dict)
+        terminal = cast(list[int], d.pop("Terminal"))
+
+        money_delta = cast(list[int], d.pop("MoneyDelta"))
+
+        shaped = cast(list[int], d.pop("Shaped"))
+
+        rewards = cls(
+            terminal=terminal,
+            money_delta=money_delta,
+            shaped=shaped,
+        )
+
+        rewards.additional_properties = d
+        return rewards
+
+    @property
+    def additional_keys(self) -> list[str]:
+        return list(self.additional_properties.keys())
+
+    def __getitem__(self, key: str) -> Any:
+        return self.additional_properties[key]
+
+    def __setitem__(self, key: str, value: Any) -> None:
+        self.additional_properties[key] = value
+
+    def __delitem__(self, key: str) -> None:
+        del self.additional_properties[key]
+
+    def __contains__(self, key: str) -> bool:
+        return key in self.additional_properties
@@ -0,0 +1,30 @@
+# Code generated by binproto_pybindgen; DO NOT EDIT.
+
+import argparse
+import pathlib
+
+from ._client import APIError, JouleQuestBinaryClient
+
+if __name__ == "__main__":
+    parser = argparse.ArgumentParser(
+        usage="Plays a game against a rest_api server started with -protocol=binary"
+    )
+    parser.add_argument(
+        "--socket", type=pathlib.Path, required=True, help="Path to the server's unix socket"
+    )
+    args = parser.parse_args()
+
+    with JouleQuestBinaryClient.connect(args.socket) as client:
+        game = client.new_game(num_players=2, seed=0)
+        print(f"Created game {game.id} in round {game.game.round}")
+        while game.game.status == "Ongoing":
+            game = client.action(id=game.id, action=game.possible_actions[0])
+        print(f"Game finished with status {game.game.status} after round {game.game.round}")
+        print(f"The log has {len(client.get_log(id=game.id).events)} events")
+
+        client.delete_game(id=game.id)
+        try:
+            client.get_state(id=game.id)
+        except APIError as e:
+            assert e.status == 404
+        print("Deleted the game")
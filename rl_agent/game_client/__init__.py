@@ -5,7 +5,8 @@ import httpx
 
 from apiclient import joule_quest_api_client as client
 from apiclient.joule_quest_api_client.api.default import post_new, delete_g_game_id, post_g_game_id_action, get_g_game_id_log
-from apiclient.joule_quest_api_client.models import GameUpdate, Error, PlayerAction, Game
+from apiclient.joule_quest_api_client.models import GameUpdate, GameParameters, Error, PlayerAction, Game
+from apiclient.joule_quest_api_client.types import UNSET
 
 @contextlib.contextmanager
 def ServerClient(executable: str, socket_path: str, suppress_output: bool=False):
@@ -45,12 +46,12 @@ class GameError(Exception):
 
 
 class GameClient:
-    def __init__(self, client: client.Client, num_players: int):
+    def __init__(self, client: client.Client, num_players: int, params: GameParameters | None = None, seed: int | None = None):
         self._client = client
         self._last_update: GameUpdate | None = None
         self._active = False
 
-        self._new_game(num_players=num_players)
+        self._new_game(num_players=num_players, params=params, seed=seed)
         
     @property
     def id(self) -> str:
@@ -59,6 +60,13 @@ class GameClient:
         else:
             return ""
 
+    @property
+    def seed(self) -> int | None:
+        if self._last_update:
+            return self._last_update.seed
+        else:
+            return None
+
     @property
     def possible_actions(self) -> list[PlayerAction]:
         if not self._last_update or not self._last_update.possible_actions:
@@ -71,8 +79,13 @@ class GameClient:
             raise GameError("Not Initialized")
         return self._last_update.game
 
-    def _new_game(self, num_players: int) -> None:
-        r = post_new.sync(client=self._client, num_players=num_players)
+    def _new_game(self, num_players: int, params: GameParameters | None, seed: int | None) -> None:
+        r = post_new.sync(
+            client=self._client,
+            num_players=num_players,
+            body=params if params is not None else UNSET,
+            seed=seed if seed is not None else UNSET,
+        )
         if isinstance(r, Error):
             raise GameError(r.error)
         elif r is None:
@@ -1,5 +1,35 @@
 # Code generated by wasm_pybindgen; DO NOT EDIT.
 
 from ._client import JouleQuestWasm
+from ._enums import (
+    RewardScheme,
+    Visibility,
+    CapacityRule,
+    CarbonTaxRule,
+    WinConditionRule,
+    GenerationConstraintRule,
+    TakeoverRule,
+    Field,
+    PnLTable,
+    ValidationError,
+    ErrCode,
+    ObsField,
+    ObsOpponentField,
+)
 
-__all__ = ["JouleQuestWasm"]
+__all__ = [
+    "JouleQuestWasm",
+    "RewardScheme",
+    "Visibility",
+    "CapacityRule",
+    "CarbonTaxRule",
+    "WinConditionRule",
+    "GenerationConstraintRule",
+    "TakeoverRule",
+    "Field",
+    "PnLTable",
+    "ValidationError",
+    "ErrCode",
+    "ObsField",
+    "ObsOpponentField",
+]
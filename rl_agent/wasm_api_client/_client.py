@@ -1,6 +1,8 @@
 # Code generated by wasm_pybindgen; DO NOT EDIT.
 
+from collections.abc import Sequence
 import os
+import struct
 from typing import Final
 
 from ._api_check import check_exports, FuncType, ValType
@@ -15,6 +17,66 @@ _EXPECTED_EXPORTS: Final[frozenset[FuncType]] = frozenset(
             params=(),
             result=(),
         ),
+        FuncType(
+            "MaxBatchSize",
+            params=(),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "MaxPlayers",
+            params=(),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "BatchActionsPtr",
+            params=(),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "BatchPlayersPtr",
+            params=(),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "BatchObservationsPtr",
+            params=(),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "BatchMasksPtr",
+            params=(),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "BatchRewardsPtr",
+            params=(),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "BatchDonePtr",
+            params=(),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "BatchCodesPtr",
+            params=(),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "SetBatchRewardScheme",
+            params=(ValType.I32,),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "BatchReset",
+            params=(ValType.I32, ValType.I32, ValType.I32),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "StepBatch",
+            params=(),
+            result=(ValType.I32,),
+        ),
         FuncType(
             "NumPlayers",
             params=(),
@@ -155,6 +217,111 @@ _EXPECTED_EXPORTS: Final[frozenset[FuncType]] = frozenset(
             params=(),
             result=(ValType.I32,),
         ),
+        FuncType(
+            "ObservationVersion",
+            params=(),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "ObservationSize",
+            params=(),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "ObservationPtr",
+            params=(),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "SetVisibility",
+            params=(ValType.I32,),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "SetObserver",
+            params=(ValType.I32,),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "EncodeObservation",
+            params=(ValType.I32,),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "ResetParams",
+            params=(),
+            result=(),
+        ),
+        FuncType(
+            "ValidateParams",
+            params=(),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "SetParam",
+            params=(ValType.I32, ValType.I32),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "Param",
+            params=(ValType.I32,),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "SetPnL",
+            params=(ValType.I32, ValType.I32, ValType.I32),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "SetStartingFossils",
+            params=(ValType.I32, ValType.I32),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "SetCapacityRule",
+            params=(ValType.I32,),
+            result=(),
+        ),
+        FuncType(
+            "SetCarbonTaxRule",
+            params=(ValType.I32,),
+            result=(),
+        ),
+        FuncType(
+            "SetWinConditionRule",
+            params=(ValType.I32,),
+            result=(),
+        ),
+        FuncType(
+            "SetGenerationConstraintRule",
+            params=(ValType.I32,),
+            result=(),
+        ),
+        FuncType(
+            "SetTakeoverRule",
+            params=(ValType.I32,),
+            result=(),
+        ),
+        FuncType(
+            "MaxSaveSlots",
+            params=(),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "SaveSlot",
+            params=(ValType.I32,),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "RestoreSlot",
+            params=(ValType.I32,),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "ClearSlot",
+            params=(ValType.I32,),
+            result=(ValType.I32,),
+        ),
         FuncType(
             "Reset",
             params=(ValType.I32,),
@@ -165,6 +332,21 @@ _EXPECTED_EXPORTS: Final[frozenset[FuncType]] = frozenset(
             params=(ValType.I32, ValType.I32),
             result=(ValType.I32,),
         ),
+        FuncType(
+            "Reward",
+            params=(ValType.I32, ValType.I32),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "Undo",
+            params=(),
+            result=(ValType.I32,),
+        ),
+        FuncType(
+            "MaxUndo",
+            params=(),
+            result=(ValType.I32,),
+        ),
         FuncType(
             "SetRNGSeed",
             params=(ValType.I32,),
@@ -180,12 +362,64 @@ class JouleQuestInstance:
         self._instance = wasmtime.Instance(self._store, module, ())
 
         self._funcs: dict[str, wasmtime.Func] = {}
+        self._memory: wasmtime.Memory | None = None
         for name, val in self._instance.exports(self._store).items():
             if isinstance(val, wasmtime.Func):
                 self._funcs[name] = val
+            elif isinstance(val, wasmtime.Memory):
+                self._memory = val
 
         self._funcs["_initialize"](self._store)
 
+    def read_int32s(self, ptr: int, count: int) -> list[int]:
+        """Reads count int32 values starting at address ptr of the linear memory."""
+        if self._memory is None:
+            raise ValueError("module does not export its memory")
+        data = self._memory.read(self._store, ptr, ptr + 4 * count)
+        return list(struct.unpack(f"<{count}i", data))
+
+    def write_int32s(self, ptr: int, values: Sequence[int]) -> None:
+        """Writes int32 values starting at address ptr of the linear memory."""
+        if self._memory is None:
+            raise ValueError("module does not export its memory")
+        self._memory.write(self._store, struct.pack(f"<{len(values)}i", *values), ptr)
+
+    def max_batch_size(self) -> int:
+        return self._funcs["MaxBatchSize"](self._store)
+
+    def max_players(self) -> int:
+        return self._funcs["MaxPlayers"](self._store)
+
+    def batch_actions_ptr(self) -> int:
+        return self._funcs["BatchActionsPtr"](self._store)
+
+    def batch_players_ptr(self) -> int:
+        return self._funcs["BatchPlayersPtr"](self._store)
+
+    def batch_observations_ptr(self) -> int:
+        return self._funcs["BatchObservationsPtr"](self._store)
+
+    def batch_masks_ptr(self) -> int:
+        return self._funcs["BatchMasksPtr"](self._store)
+
+    def batch_rewards_ptr(self) -> int:
+        return self._funcs["BatchRewardsPtr"](self._store)
+
+    def batch_done_ptr(self) -> int:
+        return self._funcs["BatchDonePtr"](self._store)
+
+    def batch_codes_ptr(self) -> int:
+        return self._funcs["BatchCodesPtr"](self._store)
+
+    def set_batch_reward_scheme(self, scheme: int) -> int:
+        return self._funcs["SetBatchRewardScheme"](self._store, scheme)
+
+    def batch_reset(self, *, batch_size: int, num_players: int, seed: int) -> int:
+        return self._funcs["BatchReset"](self._store, batch_size, num_players, seed)
+
+    def step_batch(self) -> int:
+        return self._funcs["StepBatch"](self._store)
+
     def num_players(self) -> int:
         return self._funcs["NumPlayers"](self._store)
 
@@ -270,12 +504,84 @@ class JouleQuestInstance:
     def max_action(self) -> int:
         return self._funcs["MaxAction"](self._store)
 
+    def observation_version(self) -> int:
+        return self._funcs["ObservationVersion"](self._store)
+
+    def observation_size(self) -> int:
+        return self._funcs["ObservationSize"](self._store)
+
+    def observation_ptr(self) -> int:
+        return self._funcs["ObservationPtr"](self._store)
+
+    def set_visibility(self, policy: int) -> int:
+        return self._funcs["SetVisibility"](self._store, policy)
+
+    def set_observer(self, player_index: int) -> int:
+        return self._funcs["SetObserver"](self._store, player_index)
+
+    def encode_observation(self, player_index: int) -> int:
+        return self._funcs["EncodeObservation"](self._store, player_index)
+
+    def reset_params(self) -> None:
+        return self._funcs["ResetParams"](self._store)
+
+    def validate_params(self) -> int:
+        return self._funcs["ValidateParams"](self._store)
+
+    def set_param(self, *, field_id: int, value: int) -> int:
+        return self._funcs["SetParam"](self._store, field_id, value)
+
+    def param(self, field_id: int) -> int:
+        return self._funcs["Param"](self._store, field_id)
+
+    def set_pn_l(self, *, table: int, volatility: int, value: int) -> int:
+        return self._funcs["SetPnL"](self._store, table, volatility, value)
+
+    def set_starting_fossils(self, *, num_players: int, value: int) -> int:
+        return self._funcs["SetStartingFossils"](self._store, num_players, value)
+
+    def set_capacity_rule(self, rule: int) -> None:
+        return self._funcs["SetCapacityRule"](self._store, rule)
+
+    def set_carbon_tax_rule(self, rule: int) -> None:
+        return self._funcs["SetCarbonTaxRule"](self._store, rule)
+
+    def set_win_condition_rule(self, rule: int) -> None:
+        return self._funcs["SetWinConditionRule"](self._store, rule)
+
+    def set_generation_constraint_rule(self, rule: int) -> None:
+        return self._funcs["SetGenerationConstraintRule"](self._store, rule)
+
+    def set_takeover_rule(self, rule: int) -> None:
+        return self._funcs["SetTakeoverRule"](self._store, rule)
+
+    def max_save_slots(self) -> int:
+        return self._funcs["MaxSaveSlots"](self._store)
+
+    def save_slot(self, slot: int) -> int:
+        return self._funcs["SaveSlot"](self._store, slot)
+
+    def restore_slot(self, slot: int) -> int:
+        return self._funcs["RestoreSlot"](self._store, slot)
+
+    def clear_slot(self, slot: int) -> int:
+        return self._funcs["ClearSlot"](self._store, slot)
+
     def reset(self, num_players: int) -> int:
         return self._funcs["Reset"](self._store, num_players)
 
     def apply_action(self, *, player_index: int, action_int: int) -> int:
         return self._funcs["ApplyAction"](self._store, player_index, action_int)
 
+    def reward(self, *, player_index: int, scheme: int) -> int:
+        return self._funcs["Reward"](self._store, player_index, scheme)
+
+    def undo(self) -> int:
+        return self._funcs["Undo"](self._store)
+
+    def max_undo(self) -> int:
+        return self._funcs["MaxUndo"](self._store)
+
     def set_rng_seed(self, seed: int) -> None:
         return self._funcs["SetRNGSeed"](self._store, seed)
 
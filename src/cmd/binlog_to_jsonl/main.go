@@ -0,0 +1,45 @@
+// Command binlog_to_jsonl converts a binary game log, as written by engine.NewBinaryLogger, to a JSON game log.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	"github.com/WillMorrison/JouleQuestCardGame/engine"
+)
+
+func main() {
+	inPath := flag.String("in", "-", "path to the binary game log, or - for stdin")
+	outPath := flag.String("out", "-", "path to write the JSONL game log to, or - for stdout")
+	flag.Parse()
+
+	var in io.Reader = os.Stdin
+	if *inPath != "-" {
+		f, err := os.Open(*inPath)
+		if err != nil {
+			log.Fatalf("Cannot open binary log: %s", err)
+		}
+		defer f.Close()
+		in = f
+	}
+	out := os.Stdout
+	if *outPath != "-" {
+		f, err := os.Create(*outPath)
+		if err != nil {
+			log.Fatalf("Cannot create JSONL log: %s", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	w := bufio.NewWriter(out)
+	if err := engine.ConvertBinaryLog(w, in); err != nil {
+		log.Fatalf("Conversion error: %s", err)
+	}
+	if err := w.Flush(); err != nil {
+		log.Fatalf("Error writing JSONL log: %s", err)
+	}
+}
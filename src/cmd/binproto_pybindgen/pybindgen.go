@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/WillMorrison/JouleQuestCardGame/internal/binproto"
+	"github.com/iancoleman/strcase"
+)
+
+//go:embed *.tmpl
+var tmplSources embed.FS
+
+// pyField is a message field, with the Python expressions to read and write it.
+type pyField struct {
+	Name       string // Python attribute name
+	Type       string // Python type annotation
+	Read       string // Expression reading the value from the _Reader r
+	Write      string // Statement writing self.<Name> to the _Writer w
+	Default    string // Default value in the dataclass, empty if the field is required
+	ArgType    string // Type annotation of the field as a client method argument
+	ArgDefault string // Default value as a client method argument, empty if the field is required
+	ArgValue   string // Expression converting the client method argument to the field value
+}
+
+type pyMessage struct {
+	Name   string
+	Fields []pyField
+}
+
+type pyOp struct {
+	Name     string // Python method name
+	Code     uint8
+	Doc      string
+	Request  pyMessage
+	Response string // Name of the response message
+}
+
+type tmplInput struct {
+	Messages []pyMessage
+	Ops      []pyOp
+}
+
+// messages collects every message type reachable from the ops, each after the messages it contains.
+type messages struct {
+	seen  map[reflect.Type]bool
+	order []pyMessage
+}
+
+// add adds the struct type t and the messages it contains.
+func (m *messages) add(t reflect.Type) (pyMessage, error) {
+	msg := pyMessage{Name: t.Name()}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		typ, read, write, err := m.expressions(f.Type, 0)
+		if err != nil {
+			return pyMessage{}, fmt.Errorf("%s.%s: %w", t.Name(), f.Name, err)
+		}
+		name := snakeName(f.Name)
+		pf := pyField{
+			Name:     name,
+			Type:     typ,
+			Read:     read,
+			Write:    write("self." + name),
+			ArgType:  typ,
+			ArgValue: name,
+		}
+		if f.Tag.Get("binproto") == "optional" {
+			pf.Default, pf.ArgDefault = zeroValue(f.Type)
+		}
+		if f.Type.Kind() == reflect.Slice {
+			pf.ArgType = "Sequence" + typ[len("list"):]
+			pf.ArgValue = "list(" + name + ")"
+		}
+		msg.Fields = append(msg.Fields, pf)
+	}
+	if !m.seen[t] {
+		m.seen[t] = true
+		m.order = append(m.order, msg)
+	}
+	return msg, nil
+}
+
+// snakeName returns the Python name of a Go field. strcase splits the plural acronym "IDs" after its I, so it is
+// lowercased first.
+func snakeName(name string) string {
+	return strcase.ToSnake(strings.ReplaceAll(name, "IDs", "Ids"))
+}
+
+// expressions returns the Python type of values of type t, an expression reading one from the _Reader r, and a
+// function returning a statement writing a value to the _Writer w. depth keeps the names of nested lambdas distinct.
+func (m *messages) expressions(t reflect.Type, depth int) (string, string, func(string) string, error) {
+	scalar := func(pyType, method string) (string, string, func(string) string, error) {
+		return pyType, "r." + method + "()", func(v string) string { return fmt.Sprintf("w.%s(%s)", method, v) }, nil
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return scalar("bool", "boolean")
+	case reflect.Int32:
+		return scalar("int", "i32")
+	case reflect.Int64:
+		return scalar("int", "i64")
+	case reflect.Uint64:
+		return scalar("int", "u64")
+	case reflect.String:
+		return scalar("str", "string")
+	case reflect.Slice, reflect.Pointer:
+		typ, read, write, err := m.expressions(t.Elem(), depth+1)
+		if err != nil {
+			return "", "", nil, err
+		}
+		x := fmt.Sprintf("x%d", depth)
+		if t.Kind() == reflect.Slice {
+			return "list[" + typ + "]", "r.array(lambda: " + read + ")", func(v string) string {
+				return fmt.Sprintf("w.array(%s, lambda %s: %s)", v, x, write(x))
+			}, nil
+		}
+		return typ + " | None", "r.optional(lambda: " + read + ")", func(v string) string {
+			return fmt.Sprintf("w.optional(%s, lambda %s: %s)", v, x, write(x))
+		}, nil
+	case reflect.Struct:
+		if _, err := m.add(t); err != nil {
+			return "", "", nil, err
+		}
+		return t.Name(), t.Name() + "._read(r)", func(v string) string { return v + "._write(w)" }, nil
+	}
+	return "", "", nil, fmt.Errorf("unsupported type %s", t)
+}
+
+// zeroValue returns the Python default for an optional field of type t, in a dataclass and as a method argument.
+func zeroValue(t reflect.Type) (string, string) {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "False", "False"
+	case reflect.Int32, reflect.Int64, reflect.Uint64:
+		return "0", "0"
+	case reflect.String:
+		return `""`, `""`
+	case reflect.Slice:
+		return "dataclasses.field(default_factory=list)", "()"
+	}
+	return "None", "None"
+}
+
+func loadInput() (tmplInput, error) {
+	m := messages{seen: make(map[reflect.Type]bool)}
+	var ti tmplInput
+	for _, op := range binproto.Ops {
+		req, err := m.add(reflect.TypeOf(op.Request))
+		if err != nil {
+			return tmplInput{}, err
+		}
+		resp, err := m.add(reflect.TypeOf(op.Response))
+		if err != nil {
+			return tmplInput{}, err
+		}
+		ti.Ops = append(ti.Ops, pyOp{
+			Name:     strcase.ToSnake(op.Name),
+			Code:     uint8(op.Op),
+			Doc:      op.Doc,
+			Request:  req,
+			Response: resp.Name,
+		})
+	}
+	// Errors are not the response of any op, but are sent instead of it
+	if _, err := m.add(reflect.TypeFor[binproto.ErrorResponse]()); err != nil {
+		return tmplInput{}, err
+	}
+	ti.Messages = m.order
+	return ti, nil
+}
+
+func generate(tmpl *template.Template, ti tmplInput, tmplName, pyPath string) error {
+	pyBuf := bytes.Buffer{}
+	if err := tmpl.ExecuteTemplate(&pyBuf, tmplName, ti); err != nil {
+		return fmt.Errorf("template execution error: %s", err)
+	}
+	log.Printf("Writing %s\n", pyPath)
+	if err := os.WriteFile(pyPath, pyBuf.Bytes(), os.FileMode(0664)); err != nil {
+		return fmt.Errorf("error writing %s: %s", pyPath, err)
+	}
+	return nil
+}
+
+func main() {
+	outDir := flag.String("out_dir", "", "path to a directory where the generated Python code should go.")
+	flag.Parse()
+	if *outDir == "" {
+		flag.Usage()
+		log.Fatal("missing -out_dir")
+	}
+
+	input, err := loadInput()
+	if err != nil {
+		log.Fatalf("Load error: %s\n", err)
+	}
+
+	templates, err := template.ParseFS(tmplSources, "*")
+	if err != nil {
+		log.Fatalf("Template parse error: %s\n", err)
+	}
+
+	if err := os.MkdirAll(*outDir, 0755); err != nil {
+		log.Fatalf("MkdirAll error: %s", err)
+	}
+	for _, name := range []string{"_messages.py", "_client.py", "__init__.py", "__main__.py"} {
+		if err := generate(templates, input, name+".tmpl", path.Join(*outDir, name)); err != nil {
+			log.Fatalf("Generate error: %s", err)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+// Command gamelog_schema writes the JSON Schema of game log events, from engine.GameLogSchema.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/WillMorrison/JouleQuestCardGame/engine"
+)
+
+func main() {
+	out := flag.String("out", "", "path to write the schema to.")
+	flag.Parse()
+	if *out == "" {
+		flag.Usage()
+		log.Fatal("missing -out")
+	}
+
+	schema, err := engine.GameLogSchema()
+	if err != nil {
+		log.Fatalf("Schema error: %s", err)
+	}
+	if err := os.WriteFile(*out, append(schema, '\n'), os.FileMode(0664)); err != nil {
+		log.Fatalf("Error writing %s: %s", *out, err)
+	}
+}
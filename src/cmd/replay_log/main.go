@@ -0,0 +1,40 @@
+// Command replay_log rebuilds a game from its JSON event log, and checks that the engine reproduces every logged grid
+// outcome, market outcome and loss. It exits with status 1 at the first divergence.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/WillMorrison/JouleQuestCardGame/engine"
+	"github.com/WillMorrison/JouleQuestCardGame/eventlog"
+)
+
+func main() {
+	logPath := flag.String("log", "", "path to a JSONL event log of one game, or - for stdin")
+	flag.Parse()
+	if *logPath == "" {
+		flag.Usage()
+		log.Fatal("missing -log")
+	}
+
+	var in io.Reader = os.Stdin
+	if *logPath != "-" {
+		f, err := os.Open(*logPath)
+		if err != nil {
+			log.Fatalf("Cannot open log: %s", err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	pgs, err := engine.Replay(eventlog.NewReader(in))
+	if err != nil {
+		log.Fatal(err)
+	}
+	gs := pgs.Game()
+	fmt.Printf("Replay matches the log: %s %s after round %d\n", gs.Status, gs.Reason, gs.Round)
+}
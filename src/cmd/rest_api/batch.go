@@ -0,0 +1,105 @@
+// This file contains submission of several actions to a game in one request
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	cgame "github.com/WillMorrison/JouleQuestCardGame/compact/game"
+	"github.com/WillMorrison/JouleQuestCardGame/core"
+	"github.com/WillMorrison/JouleQuestCardGame/engine"
+)
+
+// maxBatchActions limits the number of actions in one batch, so that a request can't hold a game's lock for long.
+const maxBatchActions = 1000
+
+// Values of actionResult.Status
+const (
+	actionApplied  = "Applied"  // The action was applied
+	actionRejected = "Rejected" // The action was invalid, and the batch stopped there
+	actionSkipped  = "Skipped"  // The action was not attempted, because an earlier one was rejected or ended the build phase
+)
+
+// actionResult is the outcome of one action of a batch.
+type actionResult struct {
+	Action  engine.PlayerAction
+	Status  string
+	Rewards *rewardsResponse `json:",omitempty"` // Only set for applied actions
+	Error   *errorResponse   `json:",omitempty"` // Only set for rejected actions
+}
+
+// batchResponse is the game state observable by the seat after a batch, and the result of each of its actions.
+type batchResponse struct {
+	gameResponse
+	Results []actionResult
+}
+
+// applyActions applies a list of actions sent by the seat in order, until one is rejected or the game leaves the build
+// phase because every player has finished building. The remaining actions are skipped, since they were chosen for a
+// state that no longer exists. Rejections are reported per action, so an error is only returned if the whole batch is
+// refused.
+func (g *game) applyActions(actions []engine.PlayerAction, seat int) ([]actionResult, *apiError) {
+	if len(actions) == 0 || len(actions) > maxBatchActions {
+		return nil, newAPIError(http.StatusBadRequest, cgame.CodeInvalidAction, fmt.Errorf("a batch must have between 1 and %d actions, got %d", maxBatchActions, len(actions)))
+	}
+	if g.hasSeats() && seat == noSeat {
+		return nil, newAPIError(http.StatusUnauthorized, cgame.CodeUnknown, errNeedSeatToken)
+	}
+
+	results := make([]actionResult, len(actions))
+	stopped := false
+	for i, pa := range actions {
+		results[i] = actionResult{Action: pa, Status: actionSkipped}
+		if stopped {
+			continue
+		}
+		round := g.pgs.Game().Round
+		if e := g.applyAction(pa, seat); e != nil {
+			results[i].Status = actionRejected
+			results[i].Error = &e.errorResponse
+			stopped = true
+			continue
+		}
+		results[i].Status = actionApplied
+		results[i].Rewards = g.rewards(seat)
+		stopped = g.pgs.Game().Round != round || g.pgs.Game().Status != core.GameStatusOngoing
+	}
+	return results, nil
+}
+
+// handleActions handles requests with a batch of actions, and returns the game state observable by the seat with the
+// result of each action.
+func (g *game) handleActions(resp http.ResponseWriter, req *http.Request, seat int) *apiError {
+	var actions []engine.PlayerAction
+	if err := json.NewDecoder(req.Body).Decode(&actions); err != nil {
+		return newAPIError(http.StatusBadRequest, cgame.CodeInvalidAction, fmt.Errorf("cannot read actions: %w", err))
+	}
+	results, e := g.applyActions(actions, seat)
+	if e != nil {
+		return e
+	}
+
+	s := batchResponse{gameResponse: g.getState(seat), Results: results}
+	resp.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(resp).Encode(s); err != nil {
+		resp.WriteHeader(http.StatusInternalServerError)
+		resp.Write([]byte(err.Error()))
+	}
+	return nil
+}
+
+// actionsHandler posts a batch of actions to the game with the given ID, and returns its new state. Like single
+// actions, batches for a game which is still handling another request are rejected with 409 Conflict.
+func (s *server) actionsHandler() http.HandlerFunc {
+	return func(resp http.ResponseWriter, req *http.Request) {
+		game, seat, e := s.findSeat(req.PathValue("id"), bearerToken(req))
+		if e == nil {
+			e = s.act(game, func() *apiError { return game.handleActions(resp, req, seat) })
+		}
+		if e != nil {
+			writeAPIError(resp, e)
+		}
+	}
+}
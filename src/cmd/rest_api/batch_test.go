@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/WillMorrison/JouleQuestCardGame/engine"
+)
+
+type testBatchResponse struct {
+	testGameResponse
+	Results []struct {
+		Action  engine.PlayerAction
+		Status  string
+		Rewards *rewardsResponse
+		Error   *struct{ Code int }
+	}
+}
+
+func Test_server_actionsHandler(t *testing.T) {
+	finished := func(pi int) engine.PlayerAction {
+		return engine.PlayerAction{Type: engine.ActionTypeFinished, PlayerIndex: pi}
+	}
+	tests := []struct {
+		name        string
+		actions     func(created testGameResponse) []engine.PlayerAction
+		wantStatus  []string
+		wantRecords int // Actions recorded for the game
+	}{
+		{
+			name: "stops when the build phase ends",
+			actions: func(c testGameResponse) []engine.PlayerAction {
+				return []engine.PlayerAction{c.PossibleActions[0], finished(0), finished(1), finished(0)}
+			},
+			wantStatus:  []string{actionApplied, actionApplied, actionApplied, actionSkipped},
+			wantRecords: 3,
+		},
+		{
+			name: "stops at a rejected action",
+			actions: func(c testGameResponse) []engine.PlayerAction {
+				return []engine.PlayerAction{finished(0), finished(0), finished(1)}
+			},
+			wantStatus:  []string{actionApplied, actionRejected, actionSkipped},
+			wantRecords: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := newServer(newMemoryStore(), 0)
+			if err != nil {
+				t.Fatalf("newServer() error = %v", err)
+			}
+			var created testGameResponse
+			doRequest(t, s.Mux(), "POST", "/new?numPlayers=2", "", &created)
+			body, _ := json.Marshal(tt.actions(created))
+
+			var got testBatchResponse
+			rec := doRequest(t, s.Mux(), "POST", "/g/"+created.ID+"/actions", string(body), &got)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("Got status %d, want %d: %s", rec.Code, http.StatusOK, rec.Body.String())
+			}
+			if len(got.Results) != len(tt.wantStatus) {
+				t.Fatalf("Got %d results, want %d", len(got.Results), len(tt.wantStatus))
+			}
+			for i, r := range got.Results {
+				if r.Status != tt.wantStatus[i] {
+					t.Errorf("Action %d: got status %s, want %s", i, r.Status, tt.wantStatus[i])
+				}
+				if (r.Rewards != nil) != (r.Status == actionApplied) || (r.Error != nil) != (r.Status == actionRejected) {
+					t.Errorf("Action %d with status %s has rewards %v and error %v", i, r.Status, r.Rewards, r.Error)
+				}
+			}
+			if n := len(s.games[created.ID].record.Actions); n != tt.wantRecords {
+				t.Errorf("Game recorded %d actions, want %d", n, tt.wantRecords)
+			}
+		})
+	}
+}
+
+func Test_server_actionsHandler_InvalidBatch(t *testing.T) {
+	s, err := newServer(newMemoryStore(), 0)
+	if err != nil {
+		t.Fatalf("newServer() error = %v", err)
+	}
+	var created testGameResponse
+	doRequest(t, s.Mux(), "POST", "/new?numPlayers=2", "", &created)
+
+	for _, body := range []string{"", "[]", `{"Type": "Finished"}`} {
+		if rec := doRequest(t, s.Mux(), "POST", "/g/"+created.ID+"/actions", body, nil); rec.Code != http.StatusBadRequest {
+			t.Errorf("Batch %q: got status %d, want %d", body, rec.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func Test_server_actionsHandler_Seats(t *testing.T) {
+	s, created := newSeatedGame(t)
+	body, _ := json.Marshal([]engine.PlayerAction{
+		{Type: engine.ActionTypeFinished, PlayerIndex: 0},
+		{Type: engine.ActionTypeFinished, PlayerIndex: 1},
+	})
+
+	unauthorized := doRequest(t, s.Mux(), "POST", "/g/"+created.ID+"/actions", string(body), nil)
+	var got testBatchResponse
+	doSeatRequest(t, s.Mux(), "POST", "/g/"+created.ID+"/actions", string(body), created.SeatTokens[0], &got)
+
+	if unauthorized.Code != http.StatusUnauthorized {
+		t.Errorf("Batch without a token: got status %d, want %d", unauthorized.Code, http.StatusUnauthorized)
+	}
+	if len(got.Results) != 2 || got.Results[0].Status != actionApplied || got.Results[1].Status != actionRejected {
+		t.Errorf("Got results %+v, want the other seat's action rejected", got.Results)
+	}
+}
@@ -0,0 +1,386 @@
+// This file contains the binary protocol server mode, which offers the REST operations other than event streaming
+// over internal/binproto
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/WillMorrison/JouleQuestCardGame/assets"
+	cgame "github.com/WillMorrison/JouleQuestCardGame/compact/game"
+	"github.com/WillMorrison/JouleQuestCardGame/core"
+	"github.com/WillMorrison/JouleQuestCardGame/engine"
+	"github.com/WillMorrison/JouleQuestCardGame/internal/binproto"
+	"github.com/WillMorrison/JouleQuestCardGame/params"
+)
+
+// A binaryHandler handles the encoded request message of one op, and returns the response message.
+type binaryHandler func(ctx context.Context, body []byte) (any, *apiError)
+
+// binaryOp returns a handler which decodes the request message for f.
+func binaryOp[Req any](f func(ctx context.Context, req Req) (any, *apiError)) binaryHandler {
+	return func(ctx context.Context, body []byte) (any, *apiError) {
+		var req Req
+		if err := binproto.Unmarshal(body, &req); err != nil {
+			return nil, newAPIError(http.StatusBadRequest, cgame.CodeUnknown, fmt.Errorf("cannot read request: %w", err))
+		}
+		return f(ctx, req)
+	}
+}
+
+func (s *server) binaryHandlers() map[binproto.Op]binaryHandler {
+	return map[binproto.Op]binaryHandler{
+		binproto.OpListGames:      binaryOp(s.binaryListGames),
+		binproto.OpNewGame:        binaryOp(s.binaryNewGame),
+		binproto.OpGetState:       binaryOp(s.binaryGetState),
+		binproto.OpAction:         binaryOp(s.binaryAction),
+		binproto.OpActions:        binaryOp(s.binaryActions),
+		binproto.OpGetLog:         binaryOp(s.binaryGetLog),
+		binproto.OpDeleteGame:     binaryOp(s.binaryDeleteGame),
+		binproto.OpGetParams:      binaryOp(s.binaryGetParams),
+		binproto.OpDefaultParams:  binaryOp(s.binaryDefaultParams),
+		binproto.OpValidateParams: binaryOp(s.binaryValidateParams),
+	}
+}
+
+// serveBinary accepts connections on the listener and serves the binary protocol on each of them, until the listener
+// is closed. Cancelling ctx ends requests waiting for a game to change, and closes the connections.
+func (s *server) serveBinary(ctx context.Context, listener net.Listener) error {
+	handlers := s.binaryHandlers()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go s.serveBinaryConn(ctx, conn, handlers)
+	}
+}
+
+// serveBinaryConn handles the requests sent on the connection, one at a time, until the client closes it.
+func (s *server) serveBinaryConn(ctx context.Context, conn net.Conn, handlers map[binproto.Op]binaryHandler) {
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	r := bufio.NewReader(conn)
+	for {
+		frame, err := binproto.ReadFrame(r)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && ctx.Err() == nil {
+				log.Printf("Closing binary connection: %s", err)
+			}
+			return
+		}
+		if err := binproto.WriteFrame(conn, handleBinaryRequest(ctx, handlers, frame)); err != nil {
+			return
+		}
+	}
+}
+
+// handleBinaryRequest returns the payload of the response to the request in the frame.
+func handleBinaryRequest(ctx context.Context, handlers map[binproto.Op]binaryHandler, frame []byte) []byte {
+	var msg any
+	op, body, err := binproto.DecodeRequest(frame)
+	e := newAPIError(http.StatusBadRequest, cgame.CodeUnknown, fmt.Errorf("unknown op %d", op))
+	if err != nil {
+		e = newAPIError(http.StatusBadRequest, cgame.CodeUnknown, err)
+	} else if handler, ok := handlers[op]; ok {
+		msg, e = handler(ctx, body)
+	}
+	if e == nil {
+		payload, err := binproto.EncodeResponse(http.StatusOK, msg)
+		if err == nil && len(payload) > binproto.MaxFrameSize {
+			err = fmt.Errorf("response of %d bytes exceeds the maximum frame size of %d", len(payload), binproto.MaxFrameSize)
+		}
+		if err == nil {
+			return payload
+		}
+		e = newAPIError(http.StatusInternalServerError, cgame.CodeUnknown, err)
+	}
+	payload, err := binproto.EncodeResponse(int32(e.Status), toBinaryError(e.errorResponse))
+	if err != nil {
+		panic(err) // ErrorResponse only has encodable fields
+	}
+	return payload
+}
+
+func (s *server) binaryListGames(_ context.Context, _ binproto.Empty) (any, *apiError) {
+	return binproto.GameIDs{IDs: s.gameIDs()}, nil
+}
+
+func (s *server) binaryNewGame(_ context.Context, req binproto.NewGameRequest) (any, *apiError) {
+	gameParams, err := readParams(strings.NewReader(req.Params))
+	if err != nil {
+		return nil, newAPIError(http.StatusBadRequest, cgame.CodeInvalidParam, fmt.Errorf("cannot read game parameters: %w", err))
+	}
+	visibility, err := parseVisibility(req.Visibility)
+	if err != nil {
+		return nil, newAPIError(http.StatusBadRequest, cgame.CodeInvalidParam, err)
+	}
+	seed := randomSeed()
+	if req.Seed != nil {
+		seed = *req.Seed
+	}
+	state, e := s.createGame(int(req.NumPlayers), gameParams, seed, req.SeatTokens, visibility)
+	if e != nil {
+		return nil, e
+	}
+	return toBinaryGame(state), nil
+}
+
+func (s *server) binaryGetState(ctx context.Context, req binproto.StateRequest) (any, *apiError) {
+	game, seat, e := s.findSeat(req.ID, req.Token)
+	if e != nil {
+		return nil, e
+	}
+	state, e := game.waitForState(ctx, seat, req.Wait)
+	if e != nil {
+		return nil, e
+	}
+	return toBinaryGame(state), nil
+}
+
+func (s *server) binaryAction(_ context.Context, req binproto.ActionRequest) (any, *apiError) {
+	pa, err := fromBinaryAction(req.Action)
+	if err != nil {
+		return nil, newAPIError(http.StatusBadRequest, cgame.CodeInvalidAction, fmt.Errorf("cannot read action: %w", err))
+	}
+	game, seat, e := s.findSeat(req.ID, req.Token)
+	if e != nil {
+		return nil, e
+	}
+	var state gameResponse
+	e = s.act(game, func() *apiError {
+		if e := game.applyAction(pa, seat); e != nil {
+			return e
+		}
+		state = game.actionResponse(seat)
+		return nil
+	})
+	if e != nil {
+		return nil, e
+	}
+	return toBinaryGame(state), nil
+}
+
+func (s *server) binaryActions(_ context.Context, req binproto.ActionsRequest) (any, *apiError) {
+	actions := make([]engine.PlayerAction, len(req.Actions))
+	for i, a := range req.Actions {
+		var err error
+		if actions[i], err = fromBinaryAction(a); err != nil {
+			return nil, newAPIError(http.StatusBadRequest, cgame.CodeInvalidAction, fmt.Errorf("cannot read action %d: %w", i, err))
+		}
+	}
+	game, seat, e := s.findSeat(req.ID, req.Token)
+	if e != nil {
+		return nil, e
+	}
+	var batch binproto.Batch
+	e = s.act(game, func() *apiError {
+		results, e := game.applyActions(actions, seat)
+		if e != nil {
+			return e
+		}
+		batch.Game = toBinaryGame(game.getState(seat))
+		for _, r := range results {
+			result := binproto.ActionResult{
+				Action:  toBinaryAction(r.Action),
+				Status:  r.Status,
+				Rewards: toBinaryRewards(r.Rewards),
+			}
+			if r.Error != nil {
+				be := toBinaryError(*r.Error)
+				result.Error = &be
+			}
+			batch.Results = append(batch.Results, result)
+		}
+		return nil
+	})
+	if e != nil {
+		return nil, e
+	}
+	return batch, nil
+}
+
+func (s *server) binaryGetLog(_ context.Context, req binproto.LogRequest) (any, *apiError) {
+	game, seat, e := s.findSeat(req.ID, req.Token)
+	if e != nil {
+		return nil, e
+	}
+	filter := logFilter{
+		gameEvents: req.GameEvents,
+		states:     req.States,
+		offset:     int(req.Offset),
+		limit:      int(req.Limit),
+	}
+	var negative bool
+	for _, r := range req.Rounds {
+		filter.rounds = append(filter.rounds, int(r))
+		negative = negative || r < 0
+	}
+	for _, pi := range req.PlayerIndices {
+		filter.playerIndices = append(filter.playerIndices, int(pi))
+		negative = negative || pi < 0
+	}
+	if negative || req.Offset < 0 || req.Limit < 0 {
+		return nil, newAPIError(http.StatusBadRequest, cgame.CodeInvalidParam, errors.New("log filters must not be negative"))
+	}
+	events, e := game.filteredLog(filter, seat)
+	if e != nil {
+		return nil, e
+	}
+	l := binproto.Log{Events: make([]string, len(events))}
+	for i, event := range events {
+		l.Events[i] = string(event)
+	}
+	return l, nil
+}
+
+func (s *server) binaryDeleteGame(_ context.Context, req binproto.GameRequest) (any, *apiError) {
+	if e := s.deleteGame(req.ID, req.Token); e != nil {
+		return nil, e
+	}
+	return binproto.Empty{}, nil
+}
+
+func (s *server) binaryGetParams(_ context.Context, req binproto.GameRequest) (any, *apiError) {
+	game, e := s.findGame(req.ID)
+	if e != nil {
+		return nil, e
+	}
+	return toBinaryParams(game.record.Params)
+}
+
+func (s *server) binaryDefaultParams(_ context.Context, _ binproto.Empty) (any, *apiError) {
+	return toBinaryParams(params.Default)
+}
+
+func (s *server) binaryValidateParams(_ context.Context, req binproto.Params) (any, *apiError) {
+	p, err := readParams(strings.NewReader(req.JSON))
+	if err != nil {
+		return nil, newAPIError(http.StatusBadRequest, cgame.CodeInvalidParam, fmt.Errorf("cannot read game parameters: %w", err))
+	}
+	v := validateParams(p)
+	return binproto.Validation{Valid: v.Valid, Errors: v.Errors}, nil
+}
+
+func toBinaryParams(p params.Params) (any, *apiError) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, newAPIError(http.StatusInternalServerError, cgame.CodeUnknown, err)
+	}
+	return binproto.Params{JSON: string(data)}, nil
+}
+
+func fromBinaryAction(a binproto.Action) (engine.PlayerAction, error) {
+	pa := engine.PlayerAction{PlayerIndex: int(a.PlayerIndex), Cost: int(a.Cost)}
+	if err := pa.Type.UnmarshalText([]byte(a.Type)); err != nil {
+		return engine.PlayerAction{}, err
+	}
+	if err := pa.AssetType.UnmarshalText([]byte(a.AssetType)); err != nil {
+		return engine.PlayerAction{}, err
+	}
+	return pa, nil
+}
+
+func toBinaryAction(pa engine.PlayerAction) binproto.Action {
+	return binproto.Action{
+		Type:        pa.Type.String(),
+		PlayerIndex: int32(pa.PlayerIndex),
+		AssetType:   pa.AssetType.String(),
+		Cost:        int32(pa.Cost),
+	}
+}
+
+func toBinaryActions(actions []engine.PlayerAction) []binproto.Action {
+	var converted []binproto.Action
+	for _, pa := range actions {
+		converted = append(converted, toBinaryAction(pa))
+	}
+	return converted
+}
+
+func toBinaryAssetMix(am assets.AssetMix) binproto.AssetMix {
+	return binproto.AssetMix{
+		Renewables:         int32(am.Renewables),
+		BatteriesArbitrage: int32(am.BatteriesArbitrage),
+		BatteriesCapacity:  int32(am.BatteriesCapacity),
+		FossilsWholesale:   int32(am.FossilsWholesale),
+		FossilsCapacity:    int32(am.FossilsCapacity),
+	}
+}
+
+func toInt32s(ints []int) []int32 {
+	converted := make([]int32, len(ints))
+	for i, v := range ints {
+		converted[i] = int32(v)
+	}
+	return converted
+}
+
+func toBinaryRewards(r *rewardsResponse) *binproto.Rewards {
+	if r == nil {
+		return nil
+	}
+	return &binproto.Rewards{
+		Terminal:   toInt32s(r.Terminal),
+		MoneyDelta: toInt32s(r.MoneyDelta),
+		Shaped:     toInt32s(r.Shaped),
+	}
+}
+
+func toBinaryGame(g gameResponse) binproto.Game {
+	state := binproto.State{
+		Status:           g.Game.Status,
+		Reason:           g.Game.Reason,
+		Round:            int32(g.Game.Round),
+		EmissionsCounter: int32(g.Game.EmissionsCounter),
+		LastRoundSnapshot: binproto.Snapshot{
+			AssetMix:        toBinaryAssetMix(g.Game.LastRoundSnapshot.AssetMix),
+			PriceVolatility: int32(g.Game.LastRoundSnapshot.PriceVolatility),
+			GridStability:   int32(g.Game.LastRoundSnapshot.GridStability),
+		},
+		TakeoverPool: toBinaryAssetMix(g.Game.TakeoverPool),
+		Visibility:   g.Game.Visibility,
+	}
+	for _, p := range g.Game.Players {
+		player := binproto.Player{Status: p.Status.String(), Money: int32(p.Money), Assets: toBinaryAssetMix(p.Assets)}
+		if p.Status != core.PlayerStatusActive {
+			player.Reason = p.Reason.String()
+		}
+		state.Players = append(state.Players, player)
+	}
+	return binproto.Game{
+		ID:              g.ID,
+		Seed:            g.Seed,
+		Game:            state,
+		PossibleActions: toBinaryActions(g.PossibleActions),
+		Rewards:         toBinaryRewards(g.Rewards),
+		SeatTokens:      g.SeatTokens,
+	}
+}
+
+func toBinaryError(e errorResponse) binproto.ErrorResponse {
+	be := binproto.ErrorResponse{
+		Code:         int32(e.Code),
+		Error:        e.Error,
+		LegalActions: toBinaryActions(e.LegalActions),
+	}
+	if e.Action != nil {
+		a := toBinaryAction(*e.Action)
+		be.Action = &a
+	}
+	return be
+}
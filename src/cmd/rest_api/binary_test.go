@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	cgame "github.com/WillMorrison/JouleQuestCardGame/compact/game"
+	"github.com/WillMorrison/JouleQuestCardGame/internal/binproto"
+)
+
+// dialBinary serves the binary protocol on one end of a pipe, and returns the other end.
+func dialBinary(t *testing.T, s *server) net.Conn {
+	t.Helper()
+	client, conn := net.Pipe()
+	ctx, cancel := context.WithCancel(t.Context())
+	go s.serveBinaryConn(ctx, conn, s.binaryHandlers())
+	t.Cleanup(func() {
+		client.Close()
+		cancel()
+	})
+	return client
+}
+
+// callBinaryPayload sends a request with the given payload, and returns the status and the error if it is not 200.
+// Otherwise, the response message is decoded into out.
+func callBinaryPayload(t *testing.T, conn net.Conn, payload []byte, out any) (int32, binproto.ErrorResponse) {
+	t.Helper()
+	if err := binproto.WriteFrame(conn, payload); err != nil {
+		t.Fatalf("Cannot send request: %s", err)
+	}
+	frame, err := binproto.ReadFrame(conn)
+	if err != nil {
+		t.Fatalf("Cannot read response: %s", err)
+	}
+	status, body, err := binproto.DecodeResponse(frame)
+	if err != nil {
+		t.Fatalf("Cannot decode response: %s", err)
+	}
+	var e binproto.ErrorResponse
+	if status != http.StatusOK {
+		out = &e
+	}
+	if out != nil {
+		if err := binproto.Unmarshal(body, out); err != nil {
+			t.Fatalf("Cannot decode response message with status %d: %s", status, err)
+		}
+	}
+	return status, e
+}
+
+// callBinary sends the request for the op, like callBinaryPayload.
+func callBinary(t *testing.T, conn net.Conn, op binproto.Op, req any, out any) (int32, binproto.ErrorResponse) {
+	t.Helper()
+	payload, err := binproto.EncodeRequest(op, req)
+	if err != nil {
+		t.Fatalf("Cannot encode request: %s", err)
+	}
+	return callBinaryPayload(t, conn, payload, out)
+}
+
+func Test_server_binary_MatchesREST(t *testing.T) {
+	// Arrange
+	played, playedID := newPlayedGame(t)
+	s, err := newServer(newMemoryStore(), 0)
+	if err != nil {
+		t.Fatalf("newServer() error = %v", err)
+	}
+	conn := dialBinary(t, s)
+
+	// Act: play the same game as newPlayedGame
+	seed := uint64(1)
+	var created binproto.Game
+	if status, e := callBinary(t, conn, binproto.OpNewGame, binproto.NewGameRequest{NumPlayers: 2, Seed: &seed}, &created); status != http.StatusOK {
+		t.Fatalf("NewGame got status %d: %+v", status, e)
+	}
+	for range 3 {
+		for pi := range int32(2) {
+			req := binproto.ActionRequest{ID: created.ID, Action: binproto.Action{Type: "Finished", PlayerIndex: pi, AssetType: "Renewable"}}
+			if status, e := callBinary(t, conn, binproto.OpAction, req, &binproto.Game{}); status != http.StatusOK {
+				t.Fatalf("Action got status %d: %+v", status, e)
+			}
+		}
+	}
+	var gotLog binproto.Log
+	callBinary(t, conn, binproto.OpGetLog, binproto.LogRequest{ID: created.ID}, &gotLog)
+	var gotFiltered binproto.Log
+	callBinary(t, conn, binproto.OpGetLog, binproto.LogRequest{ID: created.ID, GameEvents: []string{"MarketOutcome"}, Limit: 1}, &gotFiltered)
+	var gotState binproto.Game
+	callBinary(t, conn, binproto.OpGetState, binproto.StateRequest{ID: created.ID}, &gotState)
+	var gotParams binproto.Params
+	callBinary(t, conn, binproto.OpGetParams, binproto.GameRequest{ID: created.ID}, &gotParams)
+
+	// Assert
+	wantLog := doRequest(t, played.Mux(), "GET", "/g/"+playedID+"/log", "", nil).Body.String()
+	if got := strings.Join(gotLog.Events, "\n") + "\n"; got != wantLog {
+		t.Errorf("Got log\n%s\nwant\n%s", got, wantLog)
+	}
+	wantFiltered := doRequest(t, played.Mux(), "GET", "/g/"+playedID+"/log?game_event=MarketOutcome&limit=1", "", nil).Body.String()
+	if len(gotFiltered.Events) != 1 || gotFiltered.Events[0]+"\n" != wantFiltered {
+		t.Errorf("Got filtered log %q, want %q", gotFiltered.Events, wantFiltered)
+	}
+	var wantState testGameResponse
+	doRequest(t, played.Mux(), "GET", "/g/"+playedID, "", &wantState)
+	if len(gotState.PossibleActions) != len(wantState.PossibleActions) {
+		t.Errorf("Got %d possible actions, want %d", len(gotState.PossibleActions), len(wantState.PossibleActions))
+	}
+	wantParams := doRequest(t, played.Mux(), "GET", "/g/"+playedID+"/params", "", nil).Body.String()
+	if gotParams.JSON+"\n" != wantParams {
+		t.Errorf("Got params %s, want %s", gotParams.JSON, wantParams)
+	}
+}
+
+func Test_server_binary_Actions(t *testing.T) {
+	s, err := newServer(newMemoryStore(), 0)
+	if err != nil {
+		t.Fatalf("newServer() error = %v", err)
+	}
+	conn := dialBinary(t, s)
+	var created binproto.Game
+	callBinary(t, conn, binproto.OpNewGame, binproto.NewGameRequest{NumPlayers: 2}, &created)
+	finished := binproto.Action{Type: "Finished", PlayerIndex: 0, AssetType: "Renewable"}
+
+	var got binproto.Batch
+	status, e := callBinary(t, conn, binproto.OpActions, binproto.ActionsRequest{ID: created.ID, Actions: []binproto.Action{finished, finished, finished}}, &got)
+
+	if status != http.StatusOK {
+		t.Fatalf("Got status %d: %+v", status, e)
+	}
+	var gotStatus []string
+	for _, r := range got.Results {
+		gotStatus = append(gotStatus, r.Status)
+	}
+	if want := []string{actionApplied, actionRejected, actionSkipped}; strings.Join(gotStatus, ",") != strings.Join(want, ",") {
+		t.Errorf("Got statuses %q, want %q", gotStatus, want)
+	}
+	if got.Results[0].Rewards == nil || got.Results[1].Error == nil || got.Results[1].Error.Code != int32(cgame.CodeInvalidAction) {
+		t.Errorf("Got results %+v, want rewards for the applied action and an error for the rejected one", got.Results)
+	}
+}
+
+func Test_server_binary_HidesOpponentMoneyDelta(t *testing.T) {
+	// Arrange: each seat builds an asset, so that both players have a PnL in the operate phase
+	s, err := newServer(newMemoryStore(), 0)
+	if err != nil {
+		t.Fatalf("newServer() error = %v", err)
+	}
+	conn := dialBinary(t, s)
+	seed := uint64(1)
+	var created binproto.Game
+	callBinary(t, conn, binproto.OpNewGame, binproto.NewGameRequest{NumPlayers: 2, Seed: &seed, SeatTokens: true, Visibility: "HiddenMoney"}, &created)
+	act := func(action binproto.Action, token string) binproto.Game {
+		var got binproto.Game
+		if status, e := callBinary(t, conn, binproto.OpAction, binproto.ActionRequest{ID: created.ID, Token: token, Action: action}, &got); status != http.StatusOK {
+			t.Fatalf("Action %+v got status %d: %+v", action, status, e)
+		}
+		return got
+	}
+	for _, token := range created.SeatTokens {
+		var state binproto.Game
+		callBinary(t, conn, binproto.OpGetState, binproto.StateRequest{ID: created.ID, Token: token}, &state)
+		i := slices.IndexFunc(state.PossibleActions, func(a binproto.Action) bool { return a.Type == "BuildAsset" })
+		act(state.PossibleActions[i], token)
+	}
+	act(binproto.Action{Type: "Finished", PlayerIndex: 0, AssetType: "Renewable"}, created.SeatTokens[0])
+
+	// Act
+	got := act(binproto.Action{Type: "Finished", PlayerIndex: 1, AssetType: "Renewable"}, created.SeatTokens[1])
+
+	// Assert
+	if deltas := got.Rewards.MoneyDelta; len(deltas) != 2 || deltas[0] != 0 || deltas[1] == 0 {
+		t.Errorf("Seat 1 sees money deltas %v, want only its own", deltas)
+	}
+}
+
+func Test_server_binary_Errors(t *testing.T) {
+	s, err := newServer(newMemoryStore(), 0)
+	if err != nil {
+		t.Fatalf("newServer() error = %v", err)
+	}
+	conn := dialBinary(t, s)
+	var created binproto.Game
+	callBinary(t, conn, binproto.OpNewGame, binproto.NewGameRequest{NumPlayers: 2, SeatTokens: true}, &created)
+	finished := binproto.Action{Type: "Finished", PlayerIndex: 0, AssetType: "Renewable"}
+	encode := func(op binproto.Op, req any) []byte {
+		payload, err := binproto.EncodeRequest(op, req)
+		if err != nil {
+			t.Fatalf("Cannot encode request: %s", err)
+		}
+		return payload
+	}
+
+	tests := []struct {
+		name       string
+		payload    []byte
+		wantStatus int32
+		wantCode   cgame.ErrCode
+	}{
+		{name: "unknown op", payload: []byte{0}, wantStatus: http.StatusBadRequest, wantCode: cgame.CodeUnknown},
+		{name: "truncated request", payload: encode(binproto.OpGetState, binproto.StateRequest{ID: created.ID})[:3], wantStatus: http.StatusBadRequest, wantCode: cgame.CodeUnknown},
+		{name: "unknown game", payload: encode(binproto.OpGetState, binproto.StateRequest{ID: "nope"}), wantStatus: http.StatusNotFound, wantCode: cgame.CodeUnknown},
+		{name: "invalid params", payload: encode(binproto.OpNewGame, binproto.NewGameRequest{NumPlayers: 2, Params: `{"Nope": 1}`}), wantStatus: http.StatusBadRequest, wantCode: cgame.CodeInvalidParam},
+		{name: "no seat token", payload: encode(binproto.OpAction, binproto.ActionRequest{ID: created.ID, Action: finished}), wantStatus: http.StatusUnauthorized, wantCode: cgame.CodeUnknown},
+		{name: "other seat", payload: encode(binproto.OpAction, binproto.ActionRequest{ID: created.ID, Token: created.SeatTokens[1], Action: finished}), wantStatus: http.StatusForbidden, wantCode: cgame.CodeInvalidAction},
+		{name: "negative offset", payload: encode(binproto.OpGetLog, binproto.LogRequest{ID: created.ID, Offset: -1}), wantStatus: http.StatusBadRequest, wantCode: cgame.CodeInvalidParam},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, e := callBinaryPayload(t, conn, tt.payload, nil)
+
+			if status != tt.wantStatus || e.Code != int32(tt.wantCode) {
+				t.Errorf("Got status %d and error %+v, want status %d and code %d", status, e, tt.wantStatus, tt.wantCode)
+			}
+		})
+	}
+}
+
+func Test_handleBinaryRequest_ResponseTooLarge(t *testing.T) {
+	handlers := map[binproto.Op]binaryHandler{
+		binproto.OpGetLog: func(context.Context, []byte) (any, *apiError) {
+			return binproto.Log{Events: []string{strings.Repeat("x", binproto.MaxFrameSize)}}, nil
+		},
+	}
+
+	payload := handleBinaryRequest(t.Context(), handlers, []byte{byte(binproto.OpGetLog)})
+
+	status, body, err := binproto.DecodeResponse(payload)
+	if err != nil {
+		t.Fatalf("Cannot decode response: %s", err)
+	}
+	var e binproto.ErrorResponse
+	if err := binproto.Unmarshal(body, &e); err != nil || status != http.StatusInternalServerError {
+		t.Errorf("Got status %d and error %+v, %v, want status %d", status, e, err, http.StatusInternalServerError)
+	}
+	if len(payload) > binproto.MaxFrameSize {
+		t.Errorf("Got a response of %d bytes, which doesn't fit in a frame", len(payload))
+	}
+}
@@ -0,0 +1,162 @@
+// This file contains streaming of game events to clients as server-sent events
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	cgame "github.com/WillMorrison/JouleQuestCardGame/compact/game"
+	"github.com/WillMorrison/JouleQuestCardGame/core"
+)
+
+// eventBuffer is the io.Writer for a game's JSON logger. It keeps the JSONL log, and where each event ends, so that
+// streams can start from any event.
+type eventBuffer struct {
+	bytes.Buffer
+	ends []int // Offset in the buffer of the end of each event, including its newline
+}
+
+// Write appends to the log. The JSON logger writes exactly one whole event per call.
+func (b *eventBuffer) Write(p []byte) (int, error) {
+	n, err := b.Buffer.Write(p)
+	if bytes.HasSuffix(p, []byte("\n")) {
+		b.ends = append(b.ends, b.Len())
+	}
+	return n, err
+}
+
+// Count returns the number of events written so far.
+func (b *eventBuffer) Count() int {
+	return len(b.ends)
+}
+
+// Since returns a copy of each event from index offset on, without trailing newlines.
+func (b *eventBuffer) Since(offset int) [][]byte {
+	var events [][]byte
+	data := b.Bytes()
+	for i := offset; i < len(b.ends); i++ {
+		start := 0
+		if i > 0 {
+			start = b.ends[i-1]
+		}
+		events = append(events, bytes.Clone(bytes.TrimSuffix(data[start:b.ends[i]], []byte("\n"))))
+	}
+	return events
+}
+
+// notify wakes up all streams waiting for the game to change. The caller must hold g.mu.
+func (g *game) notify() {
+	close(g.changed)
+	g.changed = make(chan struct{})
+}
+
+// markDeleted records that the game has been removed from the server, and ends its streams. The caller must hold g.mu.
+func (g *game) markDeleted() {
+	g.deleted = true
+	g.notify()
+}
+
+// readOffset returns the index of the first event a stream should send. Reconnecting EventSource clients send the ID
+// of the last event they received in the Last-Event-ID header, which takes precedence over the offset query parameter.
+func readOffset(req *http.Request) (int, error) {
+	value := req.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = req.URL.Query().Get("offset")
+	}
+	if value == "" {
+		return 0, nil
+	}
+	offset, err := strconv.Atoi(value)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid event offset %q", value)
+	}
+	return offset, nil
+}
+
+// writeServerSentEvent writes one event in the text/event-stream format. data must not contain newlines.
+func writeServerSentEvent(resp http.ResponseWriter, id int, event string, data []byte) error {
+	_, err := fmt.Fprintf(resp, "id: %d\nevent: %s\ndata: %s\n\n", id, event, data)
+	return err
+}
+
+// eventsHandler streams the game as server-sent events. Each "log" event is one event from the game log, and is
+// followed by a "state" event with the observable game state once all events from a change have been sent. An event's
+// ID is the offset to resume from after it, so a client which reconnects with the last ID it saw misses nothing. The
+// stream ends after the final state of a finished game, or when the game is deleted. Requests with a seat token only
+// get that seat's possible actions, and both kinds of event show what the seat may see under the visibility policy.
+func (s *server) eventsHandler() http.HandlerFunc {
+	return func(resp http.ResponseWriter, req *http.Request) {
+		game := s.lookupGame(resp, req)
+		if game == nil {
+			return
+		}
+		seat, err := game.seatFor(req)
+		if err != nil {
+			writeError(resp, http.StatusUnauthorized, cgame.CodeUnknown, err)
+			return
+		}
+		offset, err := readOffset(req)
+		if err == nil {
+			game.mu.Lock()
+			if count := game.events.Count(); offset > count {
+				err = fmt.Errorf("event offset %d is beyond the %d events in the log", offset, count)
+			}
+			game.mu.Unlock()
+		}
+		if err != nil {
+			writeError(resp, http.StatusBadRequest, cgame.CodeInvalidParam, err)
+			return
+		}
+
+		rc := http.NewResponseController(resp)
+		resp.Header().Set("Content-Type", "text/event-stream")
+		resp.Header().Set("Cache-Control", "no-cache")
+		resp.WriteHeader(http.StatusOK)
+		for {
+			// Take everything needed from the game while holding its lock, then write without it
+			game.mu.Lock()
+			if game.deleted {
+				game.mu.Unlock()
+				return
+			}
+			events := game.events.Since(offset)
+			state := game.getState(seat)
+			changed := game.changed
+			game.mu.Unlock()
+			events, err := observeLog(events, seat, game.record.Visibility)
+			if err != nil {
+				return
+			}
+
+			for _, e := range events {
+				offset++
+				if err := writeServerSentEvent(resp, offset, "log", e); err != nil {
+					return
+				}
+			}
+			data, err := json.Marshal(state)
+			if err != nil {
+				return
+			}
+			if err := writeServerSentEvent(resp, offset, "state", data); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+			if state.Game.Status != core.GameStatusOngoing.String() {
+				return
+			}
+
+			select {
+			case <-changed:
+			case <-req.Context().Done():
+				return
+			}
+		}
+	}
+}
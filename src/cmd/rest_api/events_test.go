@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/WillMorrison/JouleQuestCardGame/engine"
+)
+
+type serverSentEvent struct {
+	ID    int
+	Event string
+	Data  string
+}
+
+// readServerSentEvent reads the next event from a text/event-stream body.
+func readServerSentEvent(t *testing.T, r *bufio.Reader) serverSentEvent {
+	t.Helper()
+	var e serverSentEvent
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Cannot read event: %s", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return e
+		}
+		field, value, _ := strings.Cut(line, ": ")
+		switch field {
+		case "id":
+			e.ID, _ = strconv.Atoi(value)
+		case "event":
+			e.Event = value
+		case "data":
+			e.Data = value
+		}
+	}
+}
+
+// openEventStream starts streaming the game's events, and returns a reader for the body.
+func openEventStream(t *testing.T, ts *httptest.Server, id string, lastEventID string) *bufio.Reader {
+	t.Helper()
+	req, err := http.NewRequestWithContext(t.Context(), "GET", ts.URL+"/g/"+id+"/events", nil)
+	if err != nil {
+		t.Fatalf("Cannot create request: %s", err)
+	}
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := ts.Client().Do(req)
+	if err != nil {
+		t.Fatalf("Cannot open event stream: %s", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("Got status %d and content type %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	return bufio.NewReader(resp.Body)
+}
+
+// readUntilState reads events up to and including the next state event, and returns the log events before it.
+func readUntilState(t *testing.T, r *bufio.Reader) ([]serverSentEvent, serverSentEvent) {
+	t.Helper()
+	var logEvents []serverSentEvent
+	for {
+		e := readServerSentEvent(t, r)
+		if e.Event == "state" {
+			return logEvents, e
+		}
+		logEvents = append(logEvents, e)
+	}
+}
+
+func Test_server_eventsHandler_StreamsChanges(t *testing.T) {
+	// Arrange
+	s, err := newServer(newMemoryStore(), 0)
+	if err != nil {
+		t.Fatalf("newServer() error = %v", err)
+	}
+	ts := httptest.NewServer(s.Mux())
+	t.Cleanup(ts.Close)
+	var created testGameResponse
+	doRequest(t, s.Mux(), "POST", "/new?numPlayers=2", "", &created)
+	stream := openEventStream(t, ts, created.ID, "")
+	initialLog, initialState := readUntilState(t, stream)
+
+	// Act
+	body, _ := json.Marshal(created.PossibleActions[0])
+	doRequest(t, s.Mux(), "POST", "/g/"+created.ID+"/action", string(body), nil)
+	actionLog, actionState := readUntilState(t, stream)
+
+	// Assert
+	if len(initialLog) == 0 || initialState.ID != len(initialLog) {
+		t.Errorf("Initial stream had %d log events and state ID %d, want them equal and non-zero", len(initialLog), initialState.ID)
+	}
+	if len(actionLog) != 1 || !strings.Contains(actionLog[0].Data, engine.GameLogEventPlayerAction.String()) {
+		t.Errorf("Got log events %+v after the action, want one player action", actionLog)
+	}
+	if actionState.ID != initialState.ID+1 {
+		t.Errorf("Got state ID %d after the action, want %d", actionState.ID, initialState.ID+1)
+	}
+	wantLog := doRequest(t, s.Mux(), "GET", "/g/"+created.ID+"/log", "", nil).Body.String()
+	var gotLog strings.Builder
+	for _, e := range append(initialLog, actionLog...) {
+		gotLog.WriteString(e.Data + "\n")
+	}
+	if gotLog.String() != wantLog {
+		t.Errorf("Streamed log differs from the log endpoint:\ngot  %s\nwant %s", gotLog.String(), wantLog)
+	}
+}
+
+func Test_server_eventsHandler_Resume(t *testing.T) {
+	// Arrange
+	s, err := newServer(newMemoryStore(), 0)
+	if err != nil {
+		t.Fatalf("newServer() error = %v", err)
+	}
+	ts := httptest.NewServer(s.Mux())
+	t.Cleanup(ts.Close)
+	var created testGameResponse
+	doRequest(t, s.Mux(), "POST", "/new?numPlayers=2", "", &created)
+	initialLog, _ := readUntilState(t, openEventStream(t, ts, created.ID, ""))
+	body, _ := json.Marshal(created.PossibleActions[0])
+	doRequest(t, s.Mux(), "POST", "/g/"+created.ID+"/action", string(body), nil)
+
+	// Act
+	resumed, state := readUntilState(t, openEventStream(t, ts, created.ID, strconv.Itoa(len(initialLog))))
+
+	// Assert
+	if len(resumed) != 1 || resumed[0].ID != len(initialLog)+1 {
+		t.Errorf("Resumed stream sent %+v, want only the event after ID %d", resumed, len(initialLog))
+	}
+	if state.ID != len(initialLog)+1 {
+		t.Errorf("Got state ID %d, want %d", state.ID, len(initialLog)+1)
+	}
+}
+
+func Test_server_eventsHandler_InvalidOffset(t *testing.T) {
+	s, err := newServer(newMemoryStore(), 0)
+	if err != nil {
+		t.Fatalf("newServer() error = %v", err)
+	}
+	var created testGameResponse
+	doRequest(t, s.Mux(), "POST", "/new?numPlayers=2", "", &created)
+
+	for _, offset := range []string{"-1", "x", "1000"} {
+		if rec := doRequest(t, s.Mux(), "GET", "/g/"+created.ID+"/events?offset="+offset, "", nil); rec.Code != http.StatusBadRequest {
+			t.Errorf("offset=%s: got status %d, want %d", offset, rec.Code, http.StatusBadRequest)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// This file contains filtering and pagination of game logs
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+)
+
+// logFilter selects events from a game log. Each field lists the accepted values of one event key, and an empty list
+// accepts any value. Matching events are skipped until offset of them have been seen, and at most limit are returned,
+// or all of them if limit is 0.
+type logFilter struct {
+	gameEvents    []string
+	rounds        []int
+	playerIndices []int
+	states        []string
+	offset        int
+	limit         int
+}
+
+// logEntry holds the keys of a log event that it can be filtered on.
+type logEntry struct {
+	GameEvent     string                     `json:"game_event"`
+	Round         *int                       `json:"round"`
+	PlayerIndex   *int                       `json:"player_index"`
+	State         string                     `json:"state"`
+	Action        *struct{ PlayerIndex int } `json:"action"`
+	InvalidAction *struct{ PlayerIndex int } `json:"invalid_action"`
+}
+
+// readInts parses every value of the query parameter as a non-negative integer.
+func readInts(query url.Values, key string) ([]int, error) {
+	var ints []int
+	for _, value := range query[key] {
+		i, err := strconv.Atoi(value)
+		if err != nil || i < 0 {
+			return nil, fmt.Errorf("invalid %s %q", key, value)
+		}
+		ints = append(ints, i)
+	}
+	return ints, nil
+}
+
+// readInt parses the query parameter as a non-negative integer, which is 0 if it is not set.
+func readInt(query url.Values, key string) (int, error) {
+	ints, err := readInts(query, key)
+	switch {
+	case err != nil:
+		return 0, err
+	case len(ints) > 1:
+		return 0, fmt.Errorf("%s may only be given once", key)
+	case len(ints) == 1:
+		return ints[0], nil
+	}
+	return 0, nil
+}
+
+// readLogFilter reads a filter from the query parameters game_event, round, player_index and state, which may each be
+// repeated to accept several values, and offset and limit.
+func readLogFilter(query url.Values) (logFilter, error) {
+	f := logFilter{gameEvents: query["game_event"], states: query["state"]}
+	var err error
+	if f.rounds, err = readInts(query, "round"); err != nil {
+		return logFilter{}, err
+	}
+	if f.playerIndices, err = readInts(query, "player_index"); err != nil {
+		return logFilter{}, err
+	}
+	if f.offset, err = readInt(query, "offset"); err != nil {
+		return logFilter{}, err
+	}
+	if f.limit, err = readInt(query, "limit"); err != nil {
+		return logFilter{}, err
+	}
+	return f, nil
+}
+
+// selectsAll returns whether the filter passes the whole log through unchanged.
+func (f logFilter) selectsAll() bool {
+	return len(f.gameEvents) == 0 && len(f.rounds) == 0 && len(f.playerIndices) == 0 && len(f.states) == 0 &&
+		f.offset == 0 && f.limit == 0
+}
+
+// playerIndex returns the player an event is about, either directly or through the action it logs.
+func (e logEntry) playerIndex() (int, bool) {
+	switch {
+	case e.PlayerIndex != nil:
+		return *e.PlayerIndex, true
+	case e.Action != nil:
+		return e.Action.PlayerIndex, true
+	case e.InvalidAction != nil:
+		return e.InvalidAction.PlayerIndex, true
+	}
+	return 0, false
+}
+
+func (f logFilter) matches(e logEntry) bool {
+	if len(f.gameEvents) > 0 && !slices.Contains(f.gameEvents, e.GameEvent) {
+		return false
+	}
+	if len(f.rounds) > 0 && (e.Round == nil || !slices.Contains(f.rounds, *e.Round)) {
+		return false
+	}
+	if len(f.playerIndices) > 0 {
+		if pi, ok := e.playerIndex(); !ok || !slices.Contains(f.playerIndices, pi) {
+			return false
+		}
+	}
+	if len(f.states) > 0 && !slices.Contains(f.states, e.State) {
+		return false
+	}
+	return true
+}
+
+// Apply returns the events selected by the filter.
+func (f logFilter) Apply(events [][]byte) ([][]byte, error) {
+	var selected [][]byte
+	skipped := 0
+	for _, event := range events {
+		if f.limit > 0 && len(selected) == f.limit {
+			break
+		}
+		var e logEntry
+		if err := json.Unmarshal(event, &e); err != nil {
+			return nil, fmt.Errorf("cannot decode log event %q: %w", event, err)
+		}
+		if !f.matches(e) {
+			continue
+		}
+		if skipped < f.offset {
+			skipped++
+			continue
+		}
+		selected = append(selected, event)
+	}
+	return selected, nil
+}
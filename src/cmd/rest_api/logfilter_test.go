@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/WillMorrison/JouleQuestCardGame/engine"
+)
+
+// newPlayedGame returns a server with a two player game in which both players finished building for three rounds.
+func newPlayedGame(t *testing.T) (*server, string) {
+	t.Helper()
+	s, err := newServer(newMemoryStore(), 0)
+	if err != nil {
+		t.Fatalf("newServer() error = %v", err)
+	}
+	var created testGameResponse
+	doRequest(t, s.Mux(), "POST", "/new?numPlayers=2&seed=1", "", &created)
+	for range 3 {
+		for pi := range 2 {
+			body, _ := json.Marshal(engine.PlayerAction{Type: engine.ActionTypeFinished, PlayerIndex: pi})
+			if rec := doRequest(t, s.Mux(), "POST", "/g/"+created.ID+"/action", string(body), nil); rec.Code != http.StatusOK {
+				t.Fatalf("Action failed with status %d: %s", rec.Code, rec.Body.String())
+			}
+		}
+	}
+	return s, created.ID
+}
+
+func Test_server_logHandler_Filter(t *testing.T) {
+	s, id := newPlayedGame(t)
+	tests := []struct {
+		name  string
+		query string
+		want  []string // Substrings of each returned event, in order
+	}{
+		{
+			name:  "event and round",
+			query: "game_event=MarketOutcome&round=2",
+			want:  []string{`"player_index":0,"player_money":140,"round":2`, `"player_index":1,"player_money":140,"round":2`},
+		},
+		{
+			name:  "player index matches actions",
+			query: "player_index=1&round=1",
+			want:  []string{`"PlayerIndex":1`, `"game_event":"MarketOutcome","player_PnL":45`},
+		},
+		{
+			name:  "repeated values",
+			query: "game_event=GridOutcome&game_event=EventDrawn&round=3",
+			want:  []string{`"game_event":"EventDrawn"`, `"game_event":"GridOutcome"`},
+		},
+		{
+			name:  "state",
+			query: "state=StateMachineStateBuildPhase&round=3",
+			want:  []string{`"game_event":"StateMachineTransition"`, `"game_event":"PlayerAction"`, `"game_event":"PlayerAction"`},
+		},
+		{
+			name:  "offset and limit",
+			query: "game_event=MarketOutcome&offset=3&limit=2",
+			want:  []string{`"player_index":1,"player_money":140`, `"player_index":0,"player_money":185`},
+		},
+		{
+			name:  "offset beyond the matching events",
+			query: "game_event=GridOutcome&offset=10",
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := doRequest(t, s.Mux(), "GET", "/g/"+id+"/log?"+tt.query, "", nil)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("Got status %d: %s", rec.Code, rec.Body.String())
+			}
+			got := strings.Split(strings.TrimSuffix(rec.Body.String(), "\n"), "\n")
+			if rec.Body.Len() == 0 {
+				got = nil
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Got %d events, want %d:\n%s", len(got), len(tt.want), rec.Body.String())
+			}
+			for i := range got {
+				if !strings.Contains(got[i], tt.want[i]) {
+					t.Errorf("Event %d is %s, want it to contain %s", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_server_logHandler_InvalidFilter(t *testing.T) {
+	s, id := newPlayedGame(t)
+
+	for _, query := range []string{"round=x", "player_index=-1", "offset=1&offset=2", "limit=-5"} {
+		if rec := doRequest(t, s.Mux(), "GET", "/g/"+id+"/log?"+query, "", nil); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: got status %d, want %d", query, rec.Code, http.StatusBadRequest)
+		}
+	}
+}
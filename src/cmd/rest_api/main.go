@@ -1,5 +1,9 @@
-// This implements a REST API that allows clients to play the game. It is intended for use by a
-// single client who plays all players at once and does not make extraneous requests for game state
+// This implements a REST API that allows clients to play the game. A single client may play every player of a game,
+// or a game may be created with seat tokens, so that each player is a separate client. Each client then authorizes its
+// requests with its seat's bearer token, may only act for its own player, and sees the state and log as the game's
+// visibility policy allows that seat. Requests without a token see what a spectator would.
+// With -protocol=binary, it serves the same operations, except streaming events, in the compact binary protocol of
+// internal/binproto instead.
 package main
 
 import (
@@ -11,62 +15,116 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
+	"maps"
 	"math/rand"
+	randv2 "math/rand/v2"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/WillMorrison/JouleQuestCardGame/assets"
+	cgame "github.com/WillMorrison/JouleQuestCardGame/compact/game"
 	"github.com/WillMorrison/JouleQuestCardGame/core"
 	"github.com/WillMorrison/JouleQuestCardGame/engine"
 	"github.com/WillMorrison/JouleQuestCardGame/eventlog"
 	"github.com/WillMorrison/JouleQuestCardGame/params"
 )
 
-// writeError formats the error as JSON and writes it to the response with the given error code.
-func writeError(resp http.ResponseWriter, statusCode int, err error) {
-	resp.Header().Set("Content-Type", "application/jsonl; charset=utf-8")
+// errorResponse is the body of every error response. Code uses the compact/game ErrCode values so that clients of the
+// REST and WASM APIs can handle errors the same way. Errors with no equivalent, like an unknown game ID, use
+// CodeUnknown, and are distinguished by the HTTP status.
+type errorResponse struct {
+	Code         cgame.ErrCode         `json:"code"`
+	Error        string                `json:"error"`
+	Action       *engine.PlayerAction  `json:"action,omitempty"`        // The rejected action, for invalid actions
+	LegalActions []engine.PlayerAction `json:"legal_actions,omitempty"` // The actions that could have been sent instead
+}
+
+// writeErrorResponse writes the error as JSON to the response with the given HTTP status code.
+func writeErrorResponse(resp http.ResponseWriter, statusCode int, e errorResponse) {
+	resp.Header().Set("Content-Type", "application/json; charset=utf-8")
 	resp.WriteHeader(statusCode)
-	json.NewEncoder(resp).Encode(map[string]string{"error": err.Error()})
+	json.NewEncoder(resp).Encode(e)
+}
+
+// writeError formats the error as JSON and writes it to the response with the given HTTP status code and error code.
+func writeError(resp http.ResponseWriter, statusCode int, code cgame.ErrCode, err error) {
+	writeErrorResponse(resp, statusCode, errorResponse{Code: code, Error: err.Error()})
+}
+
+// apiError is an error response with the HTTP status it is sent with. The binary protocol sends the same status, so
+// that operations shared by both protocols can report errors once.
+type apiError struct {
+	Status int
+	errorResponse
+}
+
+// newAPIError returns an error response with the given HTTP status code and error code.
+func newAPIError(statusCode int, code cgame.ErrCode, err error) *apiError {
+	return &apiError{Status: statusCode, errorResponse: errorResponse{Code: code, Error: err.Error()}}
+}
+
+// writeAPIError writes the error as JSON to the response with its HTTP status code.
+func writeAPIError(resp http.ResponseWriter, e *apiError) {
+	writeErrorResponse(resp, e.Status, e.errorResponse)
 }
 
+// A game is safe for concurrent use only while holding mu. The server takes it for the duration of each request.
 type game struct {
-	game            *engine.GameState
-	possibleActions chan []engine.PlayerAction // possible player actions from GameState
-	nextAction      chan engine.PlayerAction   // player action to send to GameState
-	logBuf          bytes.Buffer               // The json log for the game gets written here
-	id              string                     // A unique ID for this game
+	mu      sync.Mutex
+	acting  atomic.Bool // Set while an action request is being handled, to reject concurrent ones
+	deleted bool        // Set once the game has been removed from the server, so pending requests don't save it again
+	pgs     *engine.ProceduralGameState
+	events  eventBuffer   // The json log for the game gets written here
+	changed chan struct{} // Closed and replaced whenever the game changes, see events.go
+	record  gameRecord    // Everything needed to recreate the game, see storage.go
+	stored  bool          // Whether the record has been put in the store
+	saved   int           // Number of record.Actions already in the store
 }
 
-// newGame creates a GameState and calls Run() in a goroutine
-func newGame(id string, players int, gameParams params.Params) (*game, error) {
+// newGame creates a game seeded with the given seed, ready for the first action
+func newGame(id string, players int, gameParams params.Params, seed uint64) (*game, error) {
 	g := &game{
-		id:              id,
-		possibleActions: make(chan []engine.PlayerAction),
-		nextAction:      make(chan engine.PlayerAction),
-	}
-	gs, err := engine.NewGame(
-		players,
-		gameParams,
-		eventlog.NewJsonLogger(&g.logBuf),
-		func(pas []engine.PlayerAction) engine.PlayerAction {
-			g.possibleActions <- pas
-			return <-g.nextAction
-		},
-		func() {
-			close(g.possibleActions)
+		changed: make(chan struct{}),
+		record: gameRecord{
+			ID:         id,
+			NumPlayers: players,
+			Params:     gameParams,
+			Seed:       seed,
+			Updated:    time.Now(),
 		},
-	)
+	}
+	pgs, err := engine.NewSeededProceduralGame(players, gameParams, seed, eventlog.NewJsonLogger(&g.events))
 	if err != nil {
 		return nil, err
 	}
-	g.game = gs
-	go g.game.Run()
+	g.pgs = pgs
+	return g, nil
+}
+
+// loadGame recreates a stored game by replaying its actions
+func loadGame(r gameRecord) (*game, error) {
+	g, err := newGame(r.ID, r.NumPlayers, r.Params, r.Seed)
+	if err != nil {
+		return nil, err
+	}
+	for _, pa := range r.Actions {
+		if g.pgs.Game().Status != core.GameStatusOngoing {
+			return nil, fmt.Errorf("game %s has actions after it finished", r.ID)
+		}
+		g.pgs.ApplyPlayerAction(pa) // Invalid actions are replayed too, so that the log is identical
+	}
+	g.record = r
+	g.stored, g.saved = true, len(r.Actions)
 	return g, nil
 }
 
@@ -78,35 +136,57 @@ type stateResponse struct {
 	Players           []engine.PlayerState
 	LastRoundSnapshot engine.Snapshot
 	TakeoverPool      assets.AssetMix
+	Visibility        string // Name of the core.Visibility policy applied to Players
+}
+
+// rewardsResponse holds each player's reward for the last action under every core.RewardScheme
+type rewardsResponse struct {
+	Terminal   []int
+	MoneyDelta []int
+	Shaped     []int
+}
+
+// rewards returns the rewards for the last applied action as seen by the seat under the game's visibility policy
+func (g *game) rewards(seat int) *rewardsResponse {
+	return &rewardsResponse{
+		Terminal:   g.pgs.Rewards(core.RewardSchemeTerminal),
+		MoneyDelta: observeMoneyDeltas(g.pgs.Rewards(core.RewardSchemeMoneyDelta), seat, g.record.Visibility),
+		Shaped:     g.pgs.Rewards(core.RewardSchemeShaped),
+	}
 }
 
 type gameResponse struct {
 	ID              string
+	Seed            uint64
 	Game            stateResponse
 	PossibleActions []engine.PlayerAction
+	Rewards         *rewardsResponse `json:",omitempty"` // Only set in responses to actions
+	SeatTokens      []string         `json:",omitempty"` // Only set in responses creating a game with seat tokens
 }
 
-// Returns the client-observable game state. Blocks on receive from possibleActions
-func (g *game) getState() gameResponse {
-	actions := <-g.possibleActions
+// Returns the game state observable by the given seat under the game's visibility policy. The seat only gets its own
+// possible actions.
+func (g *game) getState(seat int) gameResponse {
+	gs := g.pgs.Game()
 	return gameResponse{
-		ID: g.id,
+		ID:   g.record.ID,
+		Seed: g.record.Seed,
 		Game: stateResponse{
-			Status:            g.game.Status.String(),
-			Reason:            g.game.Reason.String(),
-			Round:             g.game.Round,
-			EmissionsCounter:  g.game.CarbonEmissions,
-			Players:           g.game.Players,
-			LastRoundSnapshot: g.game.LastSnapshot,
-			TakeoverPool:      g.game.TakeoverPool,
+			Status:            gs.Status.String(),
+			Reason:            gs.Reason.String(),
+			Round:             gs.Round,
+			EmissionsCounter:  gs.CarbonEmissions,
+			Players:           observePlayers(gs.Players, seat, g.record.Visibility),
+			LastRoundSnapshot: gs.LastSnapshot,
+			TakeoverPool:      gs.TakeoverPool,
+			Visibility:        g.record.Visibility.String(),
 		},
-		PossibleActions: actions,
+		PossibleActions: actionsForSeat(g.pgs.PossibleActions(), seat),
 	}
 }
 
-// writeStateResponse writes the observable game state to the response. It blocks on receive from possibleActions
-func (g *game) writeStateResponse(resp http.ResponseWriter) {
-	s := g.getState()
+// writeGameResponse writes the game response as JSON
+func writeGameResponse(resp http.ResponseWriter, s gameResponse) {
 	resp.Header().Set("Content-Type", "application/json; charset=utf-8")
 	if err := json.NewEncoder(resp).Encode(s); err != nil {
 		resp.WriteHeader(http.StatusInternalServerError)
@@ -114,108 +194,501 @@ func (g *game) writeStateResponse(resp http.ResponseWriter) {
 	}
 }
 
-// handleAction handles requests with the selected player action and returns the observable game state
-func (g *game) handleAction(resp http.ResponseWriter, req *http.Request) {
-	// Write the PlayerAction encoded in the request to the state machine
-	if g.game.Status == core.GameStatusOngoing {
-		var pa engine.PlayerAction
-		d := json.NewDecoder(req.Body)
-		err := d.Decode(&pa)
-		if err != nil {
-			writeError(resp, http.StatusBadRequest, err)
-			return
+// applyAction applies an action sent by the seat and records it. If the action is rejected, it returns the error
+// response. Invalid actions leave the state unchanged, and their error lists the legal actions. In games with seats,
+// the seat may only act for its own player.
+func (g *game) applyAction(pa engine.PlayerAction, seat int) *apiError {
+	if g.pgs.Game().Status != core.GameStatusOngoing {
+		return &apiError{Status: http.StatusUnprocessableEntity, errorResponse: errorResponse{
+			Code:   cgame.CodeInvalidAction,
+			Error:  "the game is over",
+			Action: &pa,
+		}}
+	}
+
+	legalActions := actionsForSeat(g.pgs.PossibleActions(), seat)
+	if g.hasSeats() && seat == noSeat {
+		return newAPIError(http.StatusUnauthorized, cgame.CodeUnknown, errNeedSeatToken)
+	}
+	if seat != noSeat && pa.PlayerIndex != seat {
+		return &apiError{Status: http.StatusForbidden, errorResponse: errorResponse{
+			Code:         cgame.CodeInvalidAction,
+			Error:        fmt.Sprintf("the seat token is for PlayerIndex %d", seat),
+			Action:       &pa,
+			LegalActions: legalActions,
+		}}
+	}
+	err := g.pgs.ApplyPlayerAction(pa)
+	// Invalid actions are recorded too, since they appear in the log
+	g.record.Actions = append(g.record.Actions, pa)
+	g.record.Finished = g.pgs.Game().Status != core.GameStatusOngoing
+	g.record.Updated = time.Now()
+	g.notify()
+	if err != nil {
+		return &apiError{Status: http.StatusUnprocessableEntity, errorResponse: errorResponse{
+			Code:         cgame.CodeInvalidAction,
+			Error:        err.Error(),
+			Action:       &pa,
+			LegalActions: legalActions,
+		}}
+	}
+	return nil
+}
+
+// actionResponse returns the game state observable by the seat, with the rewards for the last applied action.
+func (g *game) actionResponse(seat int) gameResponse {
+	s := g.getState(seat)
+	s.Rewards = g.rewards(seat)
+	return s
+}
+
+// handleAction handles requests with the selected player action and returns the game state observable by the seat,
+// with the rewards for the action.
+func (g *game) handleAction(resp http.ResponseWriter, req *http.Request, seat int) *apiError {
+	var pa engine.PlayerAction
+	d := json.NewDecoder(req.Body)
+	err := d.Decode(&pa)
+	if err != nil {
+		return newAPIError(http.StatusBadRequest, cgame.CodeInvalidAction, fmt.Errorf("cannot read action: %w", err))
+	}
+	if e := g.applyAction(pa, seat); e != nil {
+		return e
+	}
+	writeGameResponse(resp, g.actionResponse(seat))
+	return nil
+}
+
+// waitForState returns the game state observable by the seat. With wait, it first waits until the seat has possible
+// actions or the game is over, so that in games with seats each client can wait for its turn.
+func (g *game) waitForState(ctx context.Context, seat int, wait bool) (gameResponse, *apiError) {
+	for {
+		g.mu.Lock()
+		deleted := g.deleted
+		state := g.getState(seat)
+		changed := g.changed
+		g.mu.Unlock()
+		switch {
+		case deleted:
+			return gameResponse{}, newAPIError(http.StatusNotFound, cgame.CodeUnknown, fmt.Errorf("no game with id %q", g.record.ID))
+		case !wait || len(state.PossibleActions) > 0 || state.Game.Status != core.GameStatusOngoing.String():
+			return state, nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return gameResponse{}, newAPIError(http.StatusServiceUnavailable, cgame.CodeUnknown, ctx.Err())
 		}
-		g.nextAction <- pa
 	}
+}
 
-	// Write the resulting state to the response
-	g.writeStateResponse(resp)
+// filteredLog returns a copy of the events of the log selected by the filter, without trailing newlines, as observed by
+// the seat under the game's visibility policy.
+func (g *game) filteredLog(filter logFilter, seat int) ([][]byte, *apiError) {
+	// Copy the log so that the lock isn't held while filtering. The visibility never changes after a game is created.
+	g.mu.Lock()
+	events := g.events.Since(0)
+	g.mu.Unlock()
+	if !filter.selectsAll() {
+		var err error
+		if events, err = filter.Apply(events); err != nil {
+			return nil, newAPIError(http.StatusInternalServerError, cgame.CodeUnknown, err)
+		}
+	}
+	observed, err := observeLog(events, seat, g.record.Visibility)
+	if err != nil {
+		return nil, newAPIError(http.StatusInternalServerError, cgame.CodeUnknown, err)
+	}
+	return observed, nil
 }
 
-// writeLogToRequest writes the contents of the log to the response
-func (g *game) writeLogToRequest(resp http.ResponseWriter) {
+// writeLogToRequest writes the events of the log selected by the filter, as observed by the seat, to the response
+func (g *game) writeLogToRequest(resp http.ResponseWriter, filter logFilter, seat int) {
+	if filter.selectsAll() && g.record.Visibility == core.VisibilityFull {
+		// Copy the log so that the lock isn't held while writing to a slow client
+		g.mu.Lock()
+		data := bytes.Clone(g.events.Bytes())
+		g.mu.Unlock()
+		resp.Header().Set("Content-Type", "application/jsonl; charset=utf-8")
+		resp.Write(data)
+		return
+	}
+	selected, e := g.filteredLog(filter, seat)
+	if e != nil {
+		writeAPIError(resp, e)
+		return
+	}
 	resp.Header().Set("Content-Type", "application/jsonl; charset=utf-8")
-	resp.Write(g.logBuf.Bytes())
+	for _, event := range selected {
+		resp.Write(append(event, '\n'))
+	}
 }
 
-// A server manages multiple games
+// A server manages multiple games. Its mutex only guards the set of games; each game has its own lock. Where both are
+// needed, the server lock is taken first.
 type server struct {
 	mu    sync.RWMutex
 	games map[string]*game // Currently running games
 	rng   rand.Source      // RNG used to create game IDs
+	store gameStore        // Persists games across restarts
+	ttl   time.Duration    // Games not updated for this long are evicted. Zero disables eviction
 }
 
-func newServer() *server {
-	return &server{
+// newServer creates a server which persists games to the given store, and loads any games already in it.
+func newServer(store gameStore, ttl time.Duration) (*server, error) {
+	s := &server{
 		games: make(map[string]*game),
 		rng:   rand.NewSource(846254781), // Fixed RNG seed for game IDs
+		store: store,
+		ttl:   ttl,
 	}
+	records, err := store.All()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read stored games: %w", err)
+	}
+	for _, r := range records {
+		g, err := loadGame(r)
+		if err != nil {
+			log.Printf("Skipping stored game %s: %s", r.ID, err)
+			continue
+		}
+		s.games[r.ID] = g
+	}
+	if len(records) > 0 {
+		log.Printf("Loaded %d of %d stored games", len(s.games), len(records))
+	}
+	return s, nil
 }
 
-// newGame handles createion of new game with the given number of players, and returns the state
+// newID returns an unused game ID. The caller must hold s.mu.
+func (s *server) newID() string {
+	for {
+		var encodedID = make([]byte, 8)
+		binary.BigEndian.PutUint64(encodedID, uint64(s.rng.Int63()))
+		sid := base64.RawURLEncoding.EncodeToString(encodedID)
+		if _, ok := s.games[sid]; !ok {
+			return sid
+		}
+	}
+}
+
+// save persists the actions the game received since it was last saved, or the whole record if it isn't stored yet.
+// Failures are logged, since the game can still be played without persistence, and the actions are saved with the
+// next ones. The caller must hold g.mu.
+func (s *server) save(g *game) {
+	var err error
+	switch {
+	case !g.stored:
+		err = s.store.Put(g.record)
+	case g.saved < len(g.record.Actions):
+		err = s.store.Append(g.record.ID, recordUpdate{
+			Actions:  g.record.Actions[g.saved:],
+			Finished: g.record.Finished,
+			Updated:  g.record.Updated,
+		})
+	default:
+		return
+	}
+	if err != nil {
+		log.Printf("Could not save game %s: %s", g.record.ID, err)
+		return
+	}
+	g.stored, g.saved = true, len(g.record.Actions)
+}
+
+// evictExpired removes games which have not been updated within the TTL, whether finished or idle. Games which are
+// busy handling a request are not idle, and are skipped.
+func (s *server) evictExpired(now time.Time) {
+	if s.ttl <= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for sid, g := range s.games {
+		if !g.mu.TryLock() {
+			continue
+		}
+		if now.Sub(g.record.Updated) >= s.ttl {
+			delete(s.games, sid)
+			g.markDeleted()
+			if err := s.store.Delete(sid); err != nil {
+				log.Printf("Could not delete game %s: %s", sid, err)
+			}
+			log.Printf("Evicted game %s", sid)
+		}
+		g.mu.Unlock()
+	}
+}
+
+// lookupGame returns the game with the ID in the request path, or writes an error response and returns nil.
+func (s *server) lookupGame(resp http.ResponseWriter, req *http.Request) *game {
+	sid := req.PathValue("id")
+	if sid == "" {
+		writeError(resp, http.StatusInternalServerError, cgame.CodeUnknown, fmt.Errorf(`cannot look up "id" in pattern %s`, req.Pattern))
+		return nil
+	}
+	game, e := s.findGame(sid)
+	if e != nil {
+		writeAPIError(resp, e)
+		return nil
+	}
+	return game
+}
+
+// findGame returns the game with the given ID, or a 404 error if there is none.
+func (s *server) findGame(id string) (*game, *apiError) {
+	s.mu.RLock()
+	game, ok := s.games[id]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, newAPIError(http.StatusNotFound, cgame.CodeUnknown, fmt.Errorf("no game with id %q", id))
+	}
+	return game, nil
+}
+
+// findSeat returns the game with the given ID, and the seat that the token belongs to.
+func (s *server) findSeat(id, token string) (*game, int, *apiError) {
+	game, e := s.findGame(id)
+	if e != nil {
+		return nil, noSeat, e
+	}
+	seat, err := game.seatForToken(token)
+	if err != nil {
+		return nil, noSeat, newAPIError(http.StatusUnauthorized, cgame.CodeUnknown, err)
+	}
+	return game, seat, nil
+}
+
+// gameIDs returns the IDs of all games on the server.
+func (s *server) gameIDs() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.AppendSeq(make([]string, 0, len(s.games)), maps.Keys(s.games))
+}
+
+// randomSeed returns a seed for games created without one, so that they can still be replayed.
+func randomSeed() uint64 {
+	return randv2.Uint64() >> 1 // Keep the seed within the non-negative int64 range for clients
+}
+
+// createGame creates and saves a game, and returns its initial state, including its seat tokens if it has seats.
+func (s *server) createGame(numPlayers int, gameParams params.Params, seed uint64, seats bool, visibility core.Visibility) (gameResponse, *apiError) {
+	if err := gameParams.Valid(); err != nil {
+		return gameResponse{}, newAPIError(http.StatusBadRequest, cgame.CodeInvalidParam, fmt.Errorf("invalid game parameters: %w", err))
+	}
+	s.mu.Lock()
+	sid := s.newID()
+	game, err := newGame(sid, numPlayers, gameParams, seed)
+	if err == nil {
+		if seats {
+			game.record.SeatTokens = newSeatTokens(numPlayers)
+		}
+		game.record.Visibility = visibility
+		// Nobody knows the ID yet, but hold the game's lock until it has been saved in case they guess it
+		game.mu.Lock()
+		defer game.mu.Unlock()
+		s.games[sid] = game
+	}
+	s.mu.Unlock()
+	if err != nil {
+		return gameResponse{}, newAPIError(http.StatusBadRequest, cgame.CodeInvalidPlayerCount, fmt.Errorf("cannot create new game: %w", err))
+	}
+	log.Printf("Created game %s with %d players and seed %d", sid, numPlayers, seed)
+	s.save(game)
+	state := game.getState(noSeat)
+	state.SeatTokens = game.record.SeatTokens
+	return state, nil
+}
+
+// act runs f, which handles an action request, while holding the game's lock, then saves the game. Requests for a game
+// which is still handling a previous action are rejected with 409 Conflict, since their order would be ambiguous.
+func (s *server) act(game *game, f func() *apiError) *apiError {
+	if !game.acting.CompareAndSwap(false, true) {
+		return newAPIError(http.StatusConflict, cgame.CodeUnknown, fmt.Errorf("game %q is already handling an action", game.record.ID))
+	}
+	defer game.acting.Store(false)
+
+	game.mu.Lock()
+	defer game.mu.Unlock()
+	if game.deleted {
+		return newAPIError(http.StatusNotFound, cgame.CodeUnknown, fmt.Errorf("no game with id %q", game.record.ID))
+	}
+	e := f()
+	s.save(game)
+	return e
+}
+
+// deleteGame deletes a game. It is a no-op if the game doesn't exist. Only players may delete a game with seats, so the
+// token must belong to one of its seats.
+func (s *server) deleteGame(id, token string) *apiError {
+	s.mu.Lock()
+	game, ok := s.games[id]
+	if ok && game.hasSeats() {
+		if seat, err := game.seatForToken(token); err != nil || seat == noSeat {
+			s.mu.Unlock()
+			return newAPIError(http.StatusUnauthorized, cgame.CodeUnknown, errors.New("deleting this game needs a seat token"))
+		}
+	}
+	delete(s.games, id)
+	s.mu.Unlock()
+	if ok {
+		// Wait for any request in progress, which might save the game again
+		game.mu.Lock()
+		game.markDeleted()
+		game.mu.Unlock()
+	}
+	if err := s.store.Delete(id); err != nil {
+		return newAPIError(http.StatusInternalServerError, cgame.CodeUnknown, fmt.Errorf("cannot delete stored game: %w", err))
+	}
+	return nil
+}
+
+// evictLoop periodically evicts expired games until ctx is done.
+func (s *server) evictLoop(ctx context.Context) {
+	if s.ttl <= 0 {
+		return
+	}
+	ticker := time.NewTicker(min(s.ttl, time.Minute))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.evictExpired(now)
+		}
+	}
+}
+
+// readParams decodes a full or partial params.Params JSON object from r, merged over params.Default.
+// An empty body results in params.Default. Returns an error if the body cannot be decoded.
+func readParams(r io.Reader) (params.Params, error) {
+	p := params.Default
+	// Decoding a map merges into the existing one, so take a copy to avoid modifying params.Default
+	p.StartingFossilAssetsPerPlayer = maps.Clone(params.Default.StartingFossilAssetsPerPlayer)
+	d := json.NewDecoder(r)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&p); err != nil && !errors.Is(err, io.EOF) {
+		return params.Params{}, err
+	}
+	return p, nil
+}
+
+// newGame handles creation of a new game with the given number of players and parameters, and returns the state
 func (s *server) newGame() http.HandlerFunc {
 	return func(resp http.ResponseWriter, req *http.Request) {
 		var numPlayers int
-		_, err := fmt.Sscanf(req.FormValue("numPlayers"), "%d", &numPlayers)
+		_, err := fmt.Sscanf(req.URL.Query().Get("numPlayers"), "%d", &numPlayers)
 		if err != nil {
-			writeError(resp, http.StatusBadRequest, fmt.Errorf("cannot read numPlayers: %w", err))
+			writeError(resp, http.StatusBadRequest, cgame.CodeInvalidPlayerCount, fmt.Errorf("cannot read numPlayers: %w", err))
 			return
 		}
-		var encodedID = make([]byte, 8)
-		binary.BigEndian.PutUint64(encodedID, uint64(s.rng.Int63()))
-		sid := base64.RawURLEncoding.EncodeToString(encodedID)
-		// Starts the game running in a goroutine
-		game, err := newGame(sid, numPlayers, params.Default)
+		gameParams, err := readParams(req.Body)
 		if err != nil {
-			writeError(resp, http.StatusInternalServerError, fmt.Errorf("cannot create new game: %w", err))
+			writeError(resp, http.StatusBadRequest, cgame.CodeInvalidParam, fmt.Errorf("cannot read game parameters: %w", err))
 			return
 		}
-		{
-			s.mu.Lock()
-			defer s.mu.Unlock()
-			s.games[sid] = game
+		// Use the requested seed if given, otherwise pick a random one so that the game can still be replayed. Seeds
+		// are non-negative int64s, as documented, so that every client can represent them.
+		var seed uint64
+		if seedParam := req.URL.Query().Get("seed"); seedParam != "" {
+			n, err := strconv.ParseInt(seedParam, 10, 64)
+			if err == nil && n < 0 {
+				err = errors.New("seed must not be negative")
+			}
+			if err != nil {
+				writeError(resp, http.StatusBadRequest, cgame.CodeInvalidParam, fmt.Errorf("cannot read seed: %w", err))
+				return
+			}
+			seed = uint64(n)
+		} else {
+			seed = randomSeed()
+		}
+		var seats bool
+		if seatsParam := req.URL.Query().Get("seatTokens"); seatsParam != "" {
+			seats, err = strconv.ParseBool(seatsParam)
+			if err != nil {
+				writeError(resp, http.StatusBadRequest, cgame.CodeInvalidParam, fmt.Errorf("cannot read seatTokens: %w", err))
+				return
+			}
+		}
+		visibility, err := parseVisibility(req.URL.Query().Get("visibility"))
+		if err != nil {
+			writeError(resp, http.StatusBadRequest, cgame.CodeInvalidParam, err)
+			return
 		}
-		game.writeStateResponse(resp)
+		state, e := s.createGame(numPlayers, gameParams, seed, seats, visibility)
+		if e != nil {
+			writeAPIError(resp, e)
+			return
+		}
+		writeGameResponse(resp, state)
 	}
 }
 
-// actionHandler posts the latest action to the game with the given ID, and returns its new state
+// actionHandler posts the latest action to the game with the given ID, and returns its new state.
 func (s *server) actionHandler() http.HandlerFunc {
 	return func(resp http.ResponseWriter, req *http.Request) {
-		sid := req.PathValue("id")
-		if sid == "" {
-			writeError(resp, http.StatusInternalServerError, fmt.Errorf(`cannot look up "id" in pattern %s`, req.Pattern))
-			return
+		game, seat, e := s.findSeat(req.PathValue("id"), bearerToken(req))
+		if e == nil {
+			e = s.act(game, func() *apiError { return game.handleAction(resp, req, seat) })
 		}
+		if e != nil {
+			writeAPIError(resp, e)
+		}
+	}
+}
 
-		s.mu.RLock()
-		defer s.mu.RUnlock()
-		game, ok := s.games[sid]
-		if !ok {
-			writeError(resp, http.StatusNotFound, fmt.Errorf("no game with id %q", sid))
+// stateHandler returns the state of the game with the given ID. With wait=true, it waits until the requesting seat has
+// possible actions or the game is over, so that in games with seats each client can wait for its turn.
+func (s *server) stateHandler() http.HandlerFunc {
+	return func(resp http.ResponseWriter, req *http.Request) {
+		game := s.lookupGame(resp, req)
+		if game == nil {
 			return
 		}
-		game.handleAction(resp, req)
+		seat, err := game.seatFor(req)
+		if err != nil {
+			writeError(resp, http.StatusUnauthorized, cgame.CodeUnknown, err)
+			return
+		}
+		var wait bool
+		if waitParam := req.URL.Query().Get("wait"); waitParam != "" {
+			wait, err = strconv.ParseBool(waitParam)
+			if err != nil {
+				writeError(resp, http.StatusBadRequest, cgame.CodeInvalidParam, fmt.Errorf("cannot read wait: %w", err))
+				return
+			}
+		}
+		state, e := game.waitForState(req.Context(), seat, wait)
+		if e != nil {
+			if req.Context().Err() == nil {
+				writeAPIError(resp, e)
+			}
+			return
+		}
+		writeGameResponse(resp, state)
 	}
 }
 
-// logHandler returns the log for the game with the given ID
+// logHandler returns the log for the game with the given ID, optionally filtered and paginated. Under a visibility
+// policy other than Full, the log shows what the seat of the request's token may see.
 func (s *server) logHandler() http.HandlerFunc {
 	return func(resp http.ResponseWriter, req *http.Request) {
-		sid := req.PathValue("id")
-		if sid == "" {
-			writeError(resp, http.StatusInternalServerError, fmt.Errorf(`cannot look up "id" in pattern %s`, req.Pattern))
+		game := s.lookupGame(resp, req)
+		if game == nil {
 			return
 		}
-
-		s.mu.RLock()
-		defer s.mu.RUnlock()
-		game, ok := s.games[sid]
-		if !ok {
-			writeError(resp, http.StatusNotFound, fmt.Errorf("no game with id %q", sid))
+		seat, err := game.seatFor(req)
+		if err != nil {
+			writeError(resp, http.StatusUnauthorized, cgame.CodeUnknown, err)
+			return
+		}
+		filter, err := readLogFilter(req.URL.Query())
+		if err != nil {
+			writeError(resp, http.StatusBadRequest, cgame.CodeInvalidParam, err)
 			return
 		}
-		game.writeLogToRequest(resp)
+		game.writeLogToRequest(resp, filter, seat)
 	}
 }
 
@@ -224,34 +697,35 @@ func (s *server) deleteHandler() http.HandlerFunc {
 	return func(resp http.ResponseWriter, req *http.Request) {
 		sid := req.PathValue("id")
 		if sid == "" {
-			writeError(resp, http.StatusInternalServerError, fmt.Errorf(`cannot look up "id" in pattern %s`, req.Pattern))
+			writeError(resp, http.StatusInternalServerError, cgame.CodeUnknown, fmt.Errorf(`cannot look up "id" in pattern %s`, req.Pattern))
 			return
 		}
-		s.mu.Lock()
-		defer s.mu.Unlock()
-		delete(s.games, sid)
+		if e := s.deleteGame(sid, bearerToken(req)); e != nil {
+			writeAPIError(resp, e)
+		}
 	}
 }
 
 // rootHandler returns the set of game IDs
 func (s *server) rootHandler() http.HandlerFunc {
 	return func(resp http.ResponseWriter, req *http.Request) {
-		keys := make([]string, 0, len(s.games))
-		for k := range s.games {
-			keys = append(keys, k)
-		}
-
 		resp.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(resp).Encode(map[string][]string{"ids": keys})
+		json.NewEncoder(resp).Encode(map[string][]string{"ids": s.gameIDs()})
 	}
 }
 
 func (s *server) Mux() *http.ServeMux {
 	mux := http.NewServeMux()
 	mux.HandleFunc("POST /new", s.newGame())
+	mux.HandleFunc("GET /g/{id}", s.stateHandler())
 	mux.HandleFunc("POST /g/{id}/action", s.actionHandler())
+	mux.HandleFunc("POST /g/{id}/actions", s.actionsHandler())
 	mux.HandleFunc("GET /g/{id}/log", s.logHandler())
+	mux.HandleFunc("GET /g/{id}/events", s.eventsHandler())
 	mux.HandleFunc("DELETE /g/{id}", s.deleteHandler())
+	mux.HandleFunc("GET /g/{id}/params", s.paramsHandler())
+	mux.HandleFunc("GET /params/default", s.defaultParamsHandler())
+	mux.HandleFunc("POST /params/validate", s.validateParamsHandler())
 	mux.HandleFunc("GET /{$}", s.rootHandler())
 	return mux
 }
@@ -300,9 +774,34 @@ func main() {
 	var err error
 	var netAddr string
 	var socketPath string
+	var dataDir string
+	var ttl time.Duration
+	var protocol string
 	flag.StringVar(&netAddr, "addr", defaultNetAddr, "Address in host:port format. If the port is 0 or empty, an unused port will be selected.")
 	flag.StringVar(&socketPath, "socket", "", "Path to create a unix socket at. Server will listen for connections on the UNIX socket.")
+	flag.StringVar(&dataDir, "data_dir", "", "Directory to persist games in, so that they are reloaded after a restart. If empty, games are only kept in memory.")
+	flag.DurationVar(&ttl, "ttl", 0, "Evict games which have not received an action for this long, e.g. 24h. If 0, games are kept until deleted.")
+	flag.StringVar(&protocol, "protocol", "http", "Protocol to serve: http for the JSON REST API, or binary for the same operations except streaming events in the compact binary protocol of internal/binproto.")
 	flag.Parse()
+	if protocol != "http" && protocol != "binary" {
+		log.Fatalf("Unknown protocol %q, want http or binary", protocol)
+	}
+
+	var store gameStore = newMemoryStore()
+	if dataDir != "" {
+		store, err = newDirStore(dataDir)
+		if err != nil {
+			log.Fatalf("Could not open data directory: %s", err)
+		}
+		log.Printf("Persisting games in %s", dataDir)
+	}
+	s, err := newServer(store, ttl)
+	if err != nil {
+		log.Fatalf("Could not create server: %s", err)
+	}
+	evictCtx, stopEvicting := context.WithCancel(context.Background())
+	defer stopEvicting()
+	go s.evictLoop(evictCtx)
 
 	// Create listener for the server to accept connections on
 	listener, cleanup, err := getListener(netAddr, socketPath)
@@ -313,11 +812,31 @@ func main() {
 	defer cleanup()
 
 	// Handle connections on the listener, forward unexpected errors to errChan
-	httpServer := http.Server{Handler: newServer().Mux()}
+	// Streaming requests never finish on their own, so cancel their contexts when shutting down
+	baseCtx, cancelRequests := context.WithCancel(context.Background())
+	httpServer := http.Server{
+		Handler:     s.Mux(),
+		BaseContext: func(net.Listener) context.Context { return baseCtx },
+	}
+	httpServer.RegisterOnShutdown(cancelRequests)
+	serve := func() error {
+		if err := httpServer.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+	shutdown := httpServer.Shutdown
+	if protocol == "binary" {
+		log.Print("Serving the binary protocol")
+		serve = func() error { return s.serveBinary(baseCtx, listener) }
+		shutdown = func(context.Context) error {
+			cancelRequests()
+			return listener.Close()
+		}
+	}
 	errChan := make(chan error, 1)
 	go func() {
-		err := httpServer.Serve(listener)
-		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		if err := serve(); err != nil {
 			errChan <- err
 		}
 	}()
@@ -330,7 +849,7 @@ func main() {
 		log.Printf("Received OS signal: %v. Starting graceful shutdown...", sig)
 		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
 		defer cancel()
-		if err := httpServer.Shutdown(ctx); err != nil {
+		if err := shutdown(ctx); err != nil {
 			log.Printf("Shutdown error: %v", err)
 		}
 	case err := <-errChan:
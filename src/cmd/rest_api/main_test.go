@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/WillMorrison/JouleQuestCardGame/assets"
+	cgame "github.com/WillMorrison/JouleQuestCardGame/compact/game"
+	"github.com/WillMorrison/JouleQuestCardGame/engine"
+)
+
+func Test_server_actionHandler_ConflictWhileActing(t *testing.T) {
+	// Arrange
+	s, err := newServer(newMemoryStore(), 0)
+	if err != nil {
+		t.Fatalf("newServer() error = %v", err)
+	}
+	var created testGameResponse
+	doRequest(t, s.Mux(), "POST", "/new?numPlayers=2", "", &created)
+	s.games[created.ID].acting.Store(true) // Simulate an action in progress
+	body, _ := json.Marshal(created.PossibleActions[0])
+
+	// Act
+	rec := doRequest(t, s.Mux(), "POST", "/g/"+created.ID+"/action", string(body), nil)
+
+	// Assert
+	if rec.Code != http.StatusConflict {
+		t.Errorf("Got status %d, want %d: %s", rec.Code, http.StatusConflict, rec.Body.String())
+	}
+}
+
+// Test_server_ConcurrentClients is mainly useful with -race.
+func Test_server_ConcurrentClients(t *testing.T) {
+	s, err := newServer(newMemoryStore(), 0)
+	if err != nil {
+		t.Fatalf("newServer() error = %v", err)
+	}
+	mux := s.Mux()
+	var shared testGameResponse
+	doRequest(t, mux, "POST", "/new?numPlayers=2&seed=1", "", &shared)
+	finished, _ := json.Marshal(engine.PlayerAction{Type: engine.ActionTypeFinished, PlayerIndex: 0})
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var own testGameResponse
+			rec := doRequest(t, mux, "POST", fmt.Sprintf("/new?numPlayers=3&seed=%d", i), "", nil)
+			if err := json.Unmarshal(rec.Body.Bytes(), &own); err != nil {
+				t.Errorf("Cannot decode new game: %s", err)
+				return
+			}
+			for range 20 {
+				// Actions on the shared game may conflict with each other or become invalid, actions on the client's
+				// own game must succeed
+				switch rec := doRequest(t, mux, "POST", "/g/"+shared.ID+"/action", string(finished), nil); rec.Code {
+				case http.StatusOK, http.StatusConflict, http.StatusUnprocessableEntity:
+				default:
+					t.Errorf("Action on shared game: got status %d: %s", rec.Code, rec.Body.String())
+				}
+				if len(own.PossibleActions) > 0 {
+					body, _ := json.Marshal(own.PossibleActions[0])
+					rec := doRequest(t, mux, "POST", "/g/"+own.ID+"/action", string(body), nil)
+					if rec.Code != http.StatusOK {
+						t.Errorf("Action on own game: got status %d: %s", rec.Code, rec.Body.String())
+						return
+					}
+					json.Unmarshal(rec.Body.Bytes(), &own)
+				}
+				doRequest(t, mux, "GET", "/g/"+shared.ID+"/log", "", nil)
+				doRequest(t, mux, "GET", "/", "", nil)
+			}
+			doRequest(t, mux, "DELETE", "/g/"+own.ID, "", nil)
+		}()
+	}
+	wg.Wait()
+
+	if len(s.games) != 1 {
+		t.Errorf("Got %d games after clients deleted theirs, want 1", len(s.games))
+	}
+}
+
+func Test_server_actionHandler_InvalidAction(t *testing.T) {
+	// Arrange
+	s, err := newServer(newMemoryStore(), 0)
+	if err != nil {
+		t.Fatalf("newServer() error = %v", err)
+	}
+	var created testGameResponse
+	doRequest(t, s.Mux(), "POST", "/new?numPlayers=2", "", &created)
+	invalid := engine.PlayerAction{Type: engine.ActionTypeBuildAsset, PlayerIndex: 0, AssetType: assets.TypeRenewable, Cost: 1}
+	body, _ := json.Marshal(invalid)
+
+	// Act
+	var got errorResponse
+	rec := doRequest(t, s.Mux(), "POST", "/g/"+created.ID+"/action", string(body), &got)
+
+	// Assert
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Got status %d, want %d", rec.Code, http.StatusUnprocessableEntity)
+	}
+	if got.Code != cgame.CodeInvalidAction {
+		t.Errorf("Got code %d, want %d", got.Code, cgame.CodeInvalidAction)
+	}
+	if got.Action == nil || *got.Action != invalid {
+		t.Errorf("Got action %+v, want %+v", got.Action, invalid)
+	}
+	if !reflect.DeepEqual(got.LegalActions, created.PossibleActions) {
+		t.Errorf("Got legal actions %+v, want %+v", got.LegalActions, created.PossibleActions)
+	}
+}
+
+func Test_server_newGame_ErrorCodes(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		body   string
+		want   cgame.ErrCode
+	}{
+		{name: "missing players", target: "/new", want: cgame.CodeInvalidPlayerCount},
+		{name: "too many players", target: "/new?numPlayers=99", want: cgame.CodeInvalidPlayerCount},
+		{name: "bad seed", target: "/new?numPlayers=2&seed=x", want: cgame.CodeInvalidParam},
+		{name: "negative seed", target: "/new?numPlayers=2&seed=-1", want: cgame.CodeInvalidParam},
+		{name: "seed above int64", target: "/new?numPlayers=2&seed=9223372036854775808", want: cgame.CodeInvalidParam},
+		{name: "bad params", target: "/new?numPlayers=2", body: `{"InitialCash": -1}`, want: cgame.CodeInvalidParam},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := newServer(newMemoryStore(), 0)
+			if err != nil {
+				t.Fatalf("newServer() error = %v", err)
+			}
+
+			var got errorResponse
+			rec := doRequest(t, s.Mux(), "POST", tt.target, tt.body, &got)
+
+			if rec.Code != http.StatusBadRequest || got.Code != tt.want {
+				t.Errorf("Got status %d and code %d, want %d and %d: %s", rec.Code, got.Code, http.StatusBadRequest, tt.want, got.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// This file contains discovery and validation of game parameters
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	cgame "github.com/WillMorrison/JouleQuestCardGame/compact/game"
+	"github.com/WillMorrison/JouleQuestCardGame/params"
+)
+
+// validationResponse lists every reason that a set of game parameters was rejected.
+type validationResponse struct {
+	Valid  bool
+	Errors []string // One entry per validation error, empty if Valid
+}
+
+// validateParams lists every reason that the game parameters are invalid.
+func validateParams(p params.Params) validationResponse {
+	v := validationResponse{Errors: []string{}}
+	for _, err := range p.ValidationErrors() {
+		v.Errors = append(v.Errors, err.Error())
+	}
+	v.Valid = len(v.Errors) == 0
+	return v
+}
+
+// writeParams writes the game parameters as JSON
+func writeParams(resp http.ResponseWriter, p params.Params) {
+	resp.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(resp).Encode(p)
+}
+
+// defaultParamsHandler returns the parameters used for parts of a new game's parameters that aren't set
+func (s *server) defaultParamsHandler() http.HandlerFunc {
+	return func(resp http.ResponseWriter, req *http.Request) {
+		writeParams(resp, params.Default)
+	}
+}
+
+// paramsHandler returns the parameters of the game with the given ID. They never change after the game is created, so
+// the game's lock is not needed.
+func (s *server) paramsHandler() http.HandlerFunc {
+	return func(resp http.ResponseWriter, req *http.Request) {
+		game := s.lookupGame(resp, req)
+		if game == nil {
+			return
+		}
+		writeParams(resp, game.record.Params)
+	}
+}
+
+// validateParamsHandler checks full or partial game parameters, as accepted when creating a game, without creating one.
+// Parameters which can be decoded but are invalid still get a 200 response, listing each validation error.
+func (s *server) validateParamsHandler() http.HandlerFunc {
+	return func(resp http.ResponseWriter, req *http.Request) {
+		p, err := readParams(req.Body)
+		if err != nil {
+			writeError(resp, http.StatusBadRequest, cgame.CodeInvalidParam, fmt.Errorf("cannot read game parameters: %w", err))
+			return
+		}
+		resp.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(resp).Encode(validateParams(p))
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/WillMorrison/JouleQuestCardGame/params"
+)
+
+func Test_server_paramsHandlers(t *testing.T) {
+	s, err := newServer(newMemoryStore(), 0)
+	if err != nil {
+		t.Fatalf("newServer() error = %v", err)
+	}
+	var created testGameResponse
+	doRequest(t, s.Mux(), "POST", "/new?numPlayers=2", `{"InitialCash": 60}`, &created)
+
+	var defaults, gameParams params.Params
+	doRequest(t, s.Mux(), "GET", "/params/default", "", &defaults)
+	doRequest(t, s.Mux(), "GET", "/g/"+created.ID+"/params", "", &gameParams)
+
+	if !reflect.DeepEqual(defaults, params.Default) {
+		t.Errorf("Got default params %+v, want %+v", defaults, params.Default)
+	}
+	want := params.BuilderFrom(params.Default).InitialCash(60).Build()
+	if !reflect.DeepEqual(gameParams, want) {
+		t.Errorf("Got game params %+v, want %+v", gameParams, want)
+	}
+	if rec := doRequest(t, s.Mux(), "GET", "/g/nope/params", "", nil); rec.Code != http.StatusNotFound {
+		t.Errorf("Params of a missing game: got status %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func Test_server_validateParamsHandler(t *testing.T) {
+	s, err := newServer(newMemoryStore(), 0)
+	if err != nil {
+		t.Fatalf("newServer() error = %v", err)
+	}
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantErrors int
+	}{
+		{name: "defaults", body: "", wantStatus: http.StatusOK, wantErrors: 0},
+		{name: "partial", body: `{"InitialCash": 60}`, wantStatus: http.StatusOK, wantErrors: 0},
+		{name: "invalid", body: `{"InitialCash": 0, "TakeoverRule": 99}`, wantStatus: http.StatusOK, wantErrors: 6},
+		{name: "unknown field", body: `{"Nope": 1}`, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got validationResponse
+			var out any
+			if tt.wantStatus == http.StatusOK {
+				out = &got
+			}
+
+			rec := doRequest(t, s.Mux(), "POST", "/params/validate", tt.body, out)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("Got status %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if len(got.Errors) != tt.wantErrors || got.Valid != (tt.wantErrors == 0) {
+				t.Errorf("Got valid %t with errors %q, want %d errors", got.Valid, got.Errors, tt.wantErrors)
+			}
+		})
+	}
+}
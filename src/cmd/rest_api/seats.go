@@ -0,0 +1,78 @@
+// This file contains per-seat access control, for games where each player is a separate client
+
+package main
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/WillMorrison/JouleQuestCardGame/engine"
+)
+
+// noSeat is the seat of requests which are not made on behalf of a single player, e.g. from the client which plays
+// every player of a game without seat tokens, or from spectators.
+const noSeat = -1
+
+var (
+	errUnknownSeatToken = errors.New("the token does not belong to any seat of this game")
+	errNeedSeatToken    = errors.New("actions for this game need a seat token")
+)
+
+// newSeatTokens returns a random bearer token for each of numPlayers seats.
+func newSeatTokens(numPlayers int) []string {
+	tokens := make([]string, numPlayers)
+	for i := range tokens {
+		tokens[i] = rand.Text()
+	}
+	return tokens
+}
+
+// hasSeats returns whether actions for the game must be authorized with a seat token.
+func (g *game) hasSeats() bool {
+	return len(g.record.SeatTokens) > 0
+}
+
+// bearerToken returns the token sent with the request as "Authorization: Bearer <token>", or "" if there is none.
+func bearerToken(req *http.Request) string {
+	token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return ""
+	}
+	return token
+}
+
+// seatFor returns the player index whose token was sent with the request, or noSeat if there is no token.
+func (g *game) seatFor(req *http.Request) (int, error) {
+	return g.seatForToken(bearerToken(req))
+}
+
+// seatForToken returns the player index that the token belongs to, or noSeat if the token is empty. Tokens are ignored
+// for games without seats. The seat tokens never change after a game is created, so g.mu need not be held.
+func (g *game) seatForToken(token string) (int, error) {
+	if token == "" || !g.hasSeats() {
+		return noSeat, nil
+	}
+	for seat, t := range g.record.SeatTokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(t)) == 1 {
+			return seat, nil
+		}
+	}
+	return noSeat, errUnknownSeatToken
+}
+
+// actionsForSeat returns the actions the given seat may take, or all actions for noSeat.
+func actionsForSeat(actions []engine.PlayerAction, seat int) []engine.PlayerAction {
+	if seat == noSeat {
+		return actions
+	}
+	var seatActions []engine.PlayerAction
+	for _, pa := range actions {
+		if pa.PlayerIndex == seat {
+			seatActions = append(seatActions, pa)
+		}
+	}
+	return seatActions
+}
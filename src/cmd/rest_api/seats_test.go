@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/WillMorrison/JouleQuestCardGame/engine"
+)
+
+type seatedGameResponse struct {
+	testGameResponse
+	SeatTokens []string
+}
+
+// doSeatRequest is like doRequest, but authorizes the request with the given seat token.
+func doSeatRequest(t *testing.T, h http.Handler, method, target, body, token string, out any) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	h.ServeHTTP(rec, req)
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: cannot decode response %q: %s", method, target, rec.Body.String(), err)
+		}
+	}
+	return rec
+}
+
+func newSeatedGame(t *testing.T) (*server, seatedGameResponse) {
+	t.Helper()
+	s, err := newServer(newMemoryStore(), 0)
+	if err != nil {
+		t.Fatalf("newServer() error = %v", err)
+	}
+	var created seatedGameResponse
+	doRequest(t, s.Mux(), "POST", "/new?numPlayers=2&seatTokens=true", "", &created)
+	if len(created.SeatTokens) != 2 || created.SeatTokens[0] == created.SeatTokens[1] {
+		t.Fatalf("Got seat tokens %q, want 2 distinct tokens", created.SeatTokens)
+	}
+	return s, created
+}
+
+func Test_server_actionHandler_Seats(t *testing.T) {
+	finished0, _ := json.Marshal(engine.PlayerAction{Type: engine.ActionTypeFinished, PlayerIndex: 0})
+	tests := []struct {
+		name       string
+		token      func(created seatedGameResponse) string
+		wantStatus int
+	}{
+		{name: "no token", token: func(seatedGameResponse) string { return "" }, wantStatus: http.StatusUnauthorized},
+		{name: "unknown token", token: func(seatedGameResponse) string { return "nope" }, wantStatus: http.StatusUnauthorized},
+		{name: "other seat", token: func(c seatedGameResponse) string { return c.SeatTokens[1] }, wantStatus: http.StatusForbidden},
+		{name: "own seat", token: func(c seatedGameResponse) string { return c.SeatTokens[0] }, wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, created := newSeatedGame(t)
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/g/"+created.ID+"/action", strings.NewReader(string(finished0)))
+			if token := tt.token(created); token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+
+			s.Mux().ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Got status %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+func Test_server_stateHandler_OnlySeatActions(t *testing.T) {
+	s, created := newSeatedGame(t)
+
+	var got testGameResponse
+	doSeatRequest(t, s.Mux(), "GET", "/g/"+created.ID, "", created.SeatTokens[1], &got)
+
+	if len(got.PossibleActions) == 0 {
+		t.Fatalf("Seat 1 has no possible actions")
+	}
+	for _, pa := range got.PossibleActions {
+		if pa.PlayerIndex != 1 {
+			t.Errorf("Seat 1 got an action for PlayerIndex %d: %+v", pa.PlayerIndex, pa)
+		}
+	}
+}
+
+func Test_server_stateHandler_WaitForTurn(t *testing.T) {
+	// Arrange
+	s, created := newSeatedGame(t)
+	finished0, _ := json.Marshal(engine.PlayerAction{Type: engine.ActionTypeFinished, PlayerIndex: 0})
+	finished1, _ := json.Marshal(engine.PlayerAction{Type: engine.ActionTypeFinished, PlayerIndex: 1})
+	doSeatRequest(t, s.Mux(), "POST", "/g/"+created.ID+"/action", string(finished0), created.SeatTokens[0], nil)
+
+	// Act
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/g/"+created.ID+"?wait=true", nil)
+		req.Header.Set("Authorization", "Bearer "+created.SeatTokens[0])
+		s.Mux().ServeHTTP(rec, req)
+		done <- rec
+	}()
+	select {
+	case <-done:
+		t.Fatalf("Waiting seat 0 returned while it had no possible actions")
+	case <-time.After(10 * time.Millisecond):
+	}
+	doSeatRequest(t, s.Mux(), "POST", "/g/"+created.ID+"/action", string(finished1), created.SeatTokens[1], nil)
+	rec := <-done
+
+	// Assert
+	var got testGameResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("Cannot decode response %q: %s", rec.Body.String(), err)
+	}
+	if len(got.PossibleActions) == 0 {
+		t.Errorf("Seat 0 has no possible actions after waiting for its turn")
+	}
+}
+
+func Test_server_deleteHandler_Seats(t *testing.T) {
+	s, created := newSeatedGame(t)
+
+	unauthorized := doRequest(t, s.Mux(), "DELETE", "/g/"+created.ID, "", nil)
+	authorized := doSeatRequest(t, s.Mux(), "DELETE", "/g/"+created.ID, "", created.SeatTokens[1], nil)
+
+	if unauthorized.Code != http.StatusUnauthorized {
+		t.Errorf("Delete without a token: got status %d, want %d", unauthorized.Code, http.StatusUnauthorized)
+	}
+	if authorized.Code != http.StatusOK {
+		t.Errorf("Delete with a seat token: got status %d, want %d", authorized.Code, http.StatusOK)
+	}
+	if _, ok := s.games[created.ID]; ok {
+		t.Errorf("Game was not deleted")
+	}
+}
@@ -0,0 +1,256 @@
+// This file contains persistent storage of games for the REST server
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/WillMorrison/JouleQuestCardGame/core"
+	"github.com/WillMorrison/JouleQuestCardGame/engine"
+	"github.com/WillMorrison/JouleQuestCardGame/params"
+)
+
+// gameRecord is the persisted form of a game. The game state and its log are recreated by replaying the actions
+// against a new game with the same parameters and seed.
+type gameRecord struct {
+	ID         string
+	NumPlayers int
+	Params     params.Params
+	Seed       uint64
+	Actions    []engine.PlayerAction // Every action sent to the game, including invalid ones
+	SeatTokens []string              `json:",omitempty"` // Tokens authorizing actions for each player, if any
+	Visibility core.Visibility       `json:",omitempty"` // Policy for what each seat sees of the other players
+	Finished   bool
+	Updated    time.Time // Time the game was created or last received an action
+}
+
+// recordUpdate holds the actions a game received since it was last saved.
+type recordUpdate struct {
+	Actions  []engine.PlayerAction
+	Finished bool
+	Updated  time.Time
+}
+
+// apply adds the update to the record.
+func (r *gameRecord) apply(u recordUpdate) {
+	r.Actions = append(r.Actions, u.Actions...)
+	r.Finished = u.Finished
+	r.Updated = u.Updated
+}
+
+// gameStore persists game records. Implementations must be safe for concurrent use.
+type gameStore interface {
+	// Put creates or replaces the record with the same ID.
+	Put(r gameRecord) error
+	// Append adds the update to the record with the given ID, without rewriting the actions already stored.
+	Append(id string, u recordUpdate) error
+	// Delete removes the record with the given ID. It is a no-op if there is no such record.
+	Delete(id string) error
+	// All returns every stored record.
+	All() ([]gameRecord, error)
+}
+
+// memoryStore keeps records in memory, so they only last as long as the process.
+type memoryStore struct {
+	mu      sync.Mutex
+	records map[string]gameRecord
+}
+
+var _ gameStore = (*memoryStore)(nil)
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{records: make(map[string]gameRecord)}
+}
+
+func (m *memoryStore) Put(r gameRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r.Actions = slices.Clone(r.Actions)
+	m.records[r.ID] = r
+	return nil
+}
+
+func (m *memoryStore) Append(id string, u recordUpdate) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r, ok := m.records[id]
+	if !ok {
+		return fmt.Errorf("no stored game with id %q", id)
+	}
+	r.apply(u) // The store owns r.Actions, which was cloned by Put
+	m.records[id] = r
+	return nil
+}
+
+func (m *memoryStore) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.records, id)
+	return nil
+}
+
+func (m *memoryStore) All() ([]gameRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	records := make([]gameRecord, 0, len(m.records))
+	for _, r := range m.records {
+		r.Actions = slices.Clone(r.Actions)
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+// dirStore keeps each record as a JSON file named after the game ID in a directory. Updates are appended as JSON lines
+// to a second file with the updatesFileExt extension, so that saving an action doesn't rewrite the whole record.
+type dirStore struct {
+	dir string
+}
+
+var _ gameStore = dirStore{}
+
+const (
+	recordFileExt  = ".json"
+	updatesFileExt = ".actions.jsonl"
+)
+
+// newDirStore returns a store using the given directory, creating it if needed.
+func newDirStore(dir string) (dirStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return dirStore{}, err
+	}
+	return dirStore{dir: dir}, nil
+}
+
+// path returns the path of the game's file with the given extension.
+func (d dirStore) path(id, ext string) (string, error) {
+	// IDs are URL-safe base64, but check anyway since they come from request paths
+	if id == "" || strings.ContainsAny(id, `/\.`) {
+		return "", fmt.Errorf("invalid game id %q", id)
+	}
+	return filepath.Join(d.dir, id+ext), nil
+}
+
+// Put writes the record to a temporary file and renames it, so a crash never leaves a partially written record. It
+// removes any updates appended to the record it replaces.
+func (d dirStore) Put(r gameRecord) error {
+	path, err := d.path(r.ID, recordFileExt)
+	if err != nil {
+		return err
+	}
+	updatesPath, err := d.path(r.ID, updatesFileExt)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(updatesPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	f, err := os.CreateTemp(d.dir, r.ID+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name()) // No-op after a successful rename
+	if err := json.NewEncoder(f).Encode(r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// Append writes the update as one line at the end of the record's updates file.
+func (d dirStore) Append(id string, u recordUpdate) error {
+	path, err := d.path(id, updatesFileExt)
+	if err != nil {
+		return err
+	}
+	line, err := json.Marshal(u)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (d dirStore) Delete(id string) error {
+	for _, ext := range []string{updatesFileExt, recordFileExt} {
+		path, err := d.path(id, ext)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// All returns the records that can be decoded, with their updates applied. Undecodable records, such as a file
+// truncated by a crash, are logged and skipped so that they do not stop the server from starting. Likewise, updates
+// are applied up to the first undecodable one.
+func (d dirStore) All() ([]gameRecord, error) {
+	paths, err := filepath.Glob(filepath.Join(d.dir, "*"+recordFileExt))
+	if err != nil {
+		return nil, err
+	}
+	var records []gameRecord
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var r gameRecord
+		if err := json.Unmarshal(data, &r); err != nil {
+			log.Printf("Skipping stored game record %s: %s", path, err)
+			continue
+		}
+		if err := d.applyUpdates(&r); err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+// applyUpdates applies the updates appended to the record's file.
+func (d dirStore) applyUpdates(r *gameRecord) error {
+	path, err := d.path(r.ID, updatesFileExt)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) || len(data) == 0 {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for i, line := range bytes.Split(bytes.TrimSuffix(data, []byte("\n")), []byte("\n")) {
+		var u recordUpdate
+		if err := json.Unmarshal(line, &u); err != nil {
+			log.Printf("Skipping stored updates of game %s from line %d: %s", r.ID, i+1, err)
+			break
+		}
+		r.apply(u)
+	}
+	return nil
+}
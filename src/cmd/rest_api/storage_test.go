@@ -0,0 +1,257 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/WillMorrison/JouleQuestCardGame/engine"
+	"github.com/WillMorrison/JouleQuestCardGame/params"
+)
+
+// testGameResponse is the part of gameResponse that tests need to decode. PlayerState has no JSON decoder.
+type testGameResponse struct {
+	ID              string
+	Seed            uint64
+	PossibleActions []engine.PlayerAction
+}
+
+// doRequest sends a request to the handler and decodes the JSON response into out, if it is not nil.
+func doRequest(t *testing.T, h http.Handler, method, target, body string, out any) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: cannot decode response %q: %s", method, target, rec.Body.String(), err)
+		}
+	}
+	return rec
+}
+
+func Test_dirStore_RoundTrip(t *testing.T) {
+	// Arrange
+	store, err := newDirStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("newDirStore() error = %v", err)
+	}
+	want := gameRecord{
+		ID:         "abc_-123",
+		NumPlayers: 3,
+		Params:     params.Default,
+		Seed:       42,
+		Actions:    []engine.PlayerAction{{Type: engine.ActionTypeFinished, PlayerIndex: 1}},
+		Updated:    time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	// Act
+	putErr := store.Put(want)
+	got, allErr := store.All()
+
+	// Assert
+	if putErr != nil || allErr != nil {
+		t.Fatalf("Put() error = %v, All() error = %v", putErr, allErr)
+	}
+	if len(got) != 1 || !reflect.DeepEqual(got[0], want) {
+		t.Errorf("All() = %+v, want [%+v]", got, want)
+	}
+	if err := store.Delete(want.ID); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+	if got, _ := store.All(); len(got) != 0 {
+		t.Errorf("All() after Delete() = %+v, want none", got)
+	}
+}
+
+func Test_dirStore_All_SkipsCorruptRecords(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	store, err := newDirStore(dir)
+	if err != nil {
+		t.Fatalf("newDirStore() error = %v", err)
+	}
+	want := gameRecord{ID: "good", NumPlayers: 2, Params: params.Default}
+	if err := store.Put(want); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "corrupt"+recordFileExt), []byte(`{"ID":"corrupt","NumPl`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	got, err := store.All()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("All() error = %v", err)
+	}
+	if len(got) != 1 || got[0].ID != want.ID {
+		t.Errorf("All() = %+v, want only %q", got, want.ID)
+	}
+}
+
+func Test_dirStore_Append(t *testing.T) {
+	// Arrange: the last update is cut short, as by a crash while writing it
+	dir := t.TempDir()
+	store, err := newDirStore(dir)
+	if err != nil {
+		t.Fatalf("newDirStore() error = %v", err)
+	}
+	build := engine.PlayerAction{Type: engine.ActionTypeBuildAsset, PlayerIndex: 0}
+	finished := engine.PlayerAction{Type: engine.ActionTypeFinished, PlayerIndex: 1}
+	updated := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := store.Put(gameRecord{ID: "game", NumPlayers: 2, Params: params.Default}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	for _, u := range []recordUpdate{
+		{Actions: []engine.PlayerAction{build}},
+		{Actions: []engine.PlayerAction{finished, finished}, Finished: true, Updated: updated},
+	} {
+		if err := store.Append("game", u); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+	f, err := os.OpenFile(filepath.Join(dir, "game"+updatesFileExt), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"Actions":[{"Ty`)
+	f.Close()
+
+	// Act
+	got, err := store.All()
+
+	// Assert
+	if err != nil || len(got) != 1 {
+		t.Fatalf("All() = %+v, %v, want one record", got, err)
+	}
+	if want := []engine.PlayerAction{build, finished, finished}; !reflect.DeepEqual(got[0].Actions, want) {
+		t.Errorf("Got actions %+v, want %+v", got[0].Actions, want)
+	}
+	if !got[0].Finished || !got[0].Updated.Equal(updated) {
+		t.Errorf("Got Finished %t and Updated %s, want those of the last whole update", got[0].Finished, got[0].Updated)
+	}
+	if err := store.Delete("game"); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("Delete() left %d files", len(entries))
+	}
+}
+
+func Test_dirStore_Put_InvalidID(t *testing.T) {
+	store, err := newDirStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("newDirStore() error = %v", err)
+	}
+	if err := store.Put(gameRecord{ID: "../escape"}); err == nil {
+		t.Errorf("Put() with path in ID succeeded, want error")
+	}
+}
+
+func Test_server_ReloadsStoredGames(t *testing.T) {
+	// Arrange
+	store, err := newDirStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("newDirStore() error = %v", err)
+	}
+	s, err := newServer(store, 0)
+	if err != nil {
+		t.Fatalf("newServer() error = %v", err)
+	}
+	var created testGameResponse
+	doRequest(t, s.Mux(), "POST", "/new?numPlayers=2&seed=7", "", &created)
+	for _, pa := range created.PossibleActions[:2] {
+		body, _ := json.Marshal(pa)
+		doRequest(t, s.Mux(), "POST", "/g/"+created.ID+"/action", string(body), nil)
+	}
+	wantLog := doRequest(t, s.Mux(), "GET", "/g/"+created.ID+"/log", "", nil).Body.String()
+
+	// Act
+	reloaded, err := newServer(store, 0)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("newServer() on existing store error = %v", err)
+	}
+	gotLog := doRequest(t, reloaded.Mux(), "GET", "/g/"+created.ID+"/log", "", nil).Body.String()
+	if gotLog != wantLog {
+		t.Errorf("Reloaded game log differs:\ngot  %s\nwant %s", gotLog, wantLog)
+	}
+	var update testGameResponse
+	body, _ := json.Marshal(engine.PlayerAction{Type: engine.ActionTypeFinished, PlayerIndex: 0})
+	doRequest(t, reloaded.Mux(), "POST", "/g/"+created.ID+"/action", string(body), &update)
+	if update.ID != created.ID || update.Seed != 7 {
+		t.Errorf("Action on reloaded game returned ID %q and seed %d, want %q and 7", update.ID, update.Seed, created.ID)
+	}
+}
+
+func Test_server_save_AppendsActions(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	store, err := newDirStore(dir)
+	if err != nil {
+		t.Fatalf("newDirStore() error = %v", err)
+	}
+	s, err := newServer(store, 0)
+	if err != nil {
+		t.Fatalf("newServer() error = %v", err)
+	}
+	var created testGameResponse
+	doRequest(t, s.Mux(), "POST", "/new?numPlayers=2&seed=7", "", &created)
+	recordPath := filepath.Join(dir, created.ID+recordFileExt)
+	record, err := os.ReadFile(recordPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	for pi := range 2 {
+		body, _ := json.Marshal(engine.PlayerAction{Type: engine.ActionTypeFinished, PlayerIndex: pi})
+		doRequest(t, s.Mux(), "POST", "/g/"+created.ID+"/action", string(body), nil)
+	}
+
+	// Assert
+	if got, _ := os.ReadFile(recordPath); string(got) != string(record) {
+		t.Errorf("Record was rewritten after actions:\ngot  %s\nwant %s", got, record)
+	}
+	updates, err := os.ReadFile(filepath.Join(dir, created.ID+updatesFileExt))
+	if err != nil || strings.Count(string(updates), "\n") != 2 {
+		t.Errorf("Got updates %q, %v, want one line per action", updates, err)
+	}
+}
+
+func Test_server_evictExpired(t *testing.T) {
+	// Arrange
+	store := newMemoryStore()
+	s, err := newServer(store, time.Hour)
+	if err != nil {
+		t.Fatalf("newServer() error = %v", err)
+	}
+	var created testGameResponse
+	doRequest(t, s.Mux(), "POST", "/new?numPlayers=2", "", &created)
+	updated := s.games[created.ID].record.Updated
+
+	// Act
+	s.evictExpired(updated.Add(time.Minute))
+	_, keptEarly := s.games[created.ID]
+	s.evictExpired(updated.Add(time.Hour))
+	_, keptLate := s.games[created.ID]
+
+	// Assert
+	if !keptEarly {
+		t.Errorf("Game was evicted before the TTL")
+	}
+	if keptLate {
+		t.Errorf("Game was not evicted after the TTL")
+	}
+	if records, _ := store.All(); len(records) != 0 {
+		t.Errorf("Evicted game is still stored: %+v", records)
+	}
+}
@@ -0,0 +1,147 @@
+// This file contains the hidden-information visibility policy applied to the game state and log each seat observes
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/WillMorrison/JouleQuestCardGame/assets"
+	"github.com/WillMorrison/JouleQuestCardGame/core"
+	"github.com/WillMorrison/JouleQuestCardGame/engine"
+)
+
+// parseVisibility returns the policy with the given name, or core.VisibilityFull if the name is empty.
+func parseVisibility(name string) (core.Visibility, error) {
+	if name == "" {
+		return core.VisibilityFull, nil
+	}
+	for v := core.VisibilityFull; v <= core.VisibilityBucketedMix; v++ {
+		if v.String() == name {
+			return v, nil
+		}
+	}
+	return core.VisibilityFull, fmt.Errorf("unknown visibility policy %q", name)
+}
+
+// observeMix returns an opponent's asset mix as seen under the policy.
+func observeMix(mix assets.AssetMix, v core.Visibility) assets.AssetMix {
+	return assets.AssetMix{
+		Renewables:         v.OpponentAssetCount(mix.Renewables),
+		BatteriesArbitrage: v.OpponentAssetCount(mix.BatteriesArbitrage),
+		BatteriesCapacity:  v.OpponentAssetCount(mix.BatteriesCapacity),
+		FossilsWholesale:   v.OpponentAssetCount(mix.FossilsWholesale),
+		FossilsCapacity:    v.OpponentAssetCount(mix.FossilsCapacity),
+	}
+}
+
+// observePlayers returns every player's state as seen by the given seat under the policy. Seats always see their own
+// state in full, and requests without a seat see every player as an opponent. Hidden money reads as 0, like in the
+// compact engine's observations, so that the state has the same shape under every policy.
+func observePlayers(players []engine.PlayerState, seat int, v core.Visibility) []engine.PlayerState {
+	if v == core.VisibilityFull {
+		return players
+	}
+	observed := make([]engine.PlayerState, len(players))
+	for pi, ps := range players {
+		if pi != seat {
+			if v.HidesOpponentMoney() {
+				ps.Money = 0
+			}
+			ps.Assets = observeMix(ps.Assets, v)
+		}
+		observed[pi] = ps
+	}
+	return observed
+}
+
+// observeMoneyDeltas returns the players' money delta rewards as seen by the given seat under the policy. Opponents'
+// deltas read as 0 when their money is hidden, since they reveal changes to it.
+func observeMoneyDeltas(deltas []int, seat int, v core.Visibility) []int {
+	if !v.HidesOpponentMoney() {
+		return deltas
+	}
+	observed := make([]int, len(deltas))
+	if seat >= 0 && seat < len(deltas) {
+		observed[seat] = deltas[seat]
+	}
+	return observed
+}
+
+// observedLogEntry holds the keys of a log event that can reveal hidden information about a player. Events with
+// player_index are about that player, and players and player_funds are indexed by player.
+type observedLogEntry struct {
+	PlayerIndex *int                 `json:"player_index"`
+	AssetMix    *assets.AssetMix     `json:"player_asset_mix"`
+	Money       *int                 `json:"player_money"`
+	PnL         *int                 `json:"player_PnL"`
+	Players     []engine.PlayerState `json:"players"`
+	PlayerFunds []int                `json:"player_funds"`
+}
+
+// observeLogEvent returns the log event as seen by the given seat under the policy, with opponents' hidden values
+// replaced as in observePlayers. Events which reveal nothing hidden are returned unchanged.
+func observeLogEvent(event []byte, seat int, v core.Visibility) ([]byte, error) {
+	if v == core.VisibilityFull {
+		return event, nil
+	}
+	var e observedLogEntry
+	if err := json.Unmarshal(event, &e); err != nil {
+		return nil, fmt.Errorf("cannot decode log event %q: %w", event, err)
+	}
+	redacted := map[string]any{}
+	if e.PlayerIndex != nil && *e.PlayerIndex != seat {
+		if v.HidesOpponentMoney() {
+			if e.Money != nil {
+				redacted["player_money"] = 0
+			}
+			if e.PnL != nil {
+				redacted["player_PnL"] = 0
+			}
+		}
+		if e.AssetMix != nil && observeMix(*e.AssetMix, v) != *e.AssetMix {
+			redacted["player_asset_mix"] = observeMix(*e.AssetMix, v)
+		}
+	}
+	if e.Players != nil {
+		redacted["players"] = observePlayers(e.Players, seat, v)
+	}
+	if e.PlayerFunds != nil && v.HidesOpponentMoney() {
+		funds := make([]int, len(e.PlayerFunds))
+		if seat >= 0 && seat < len(funds) {
+			funds[seat] = e.PlayerFunds[seat]
+		}
+		redacted["player_funds"] = funds
+	}
+	if len(redacted) == 0 {
+		return event, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(event, &fields); err != nil {
+		return nil, fmt.Errorf("cannot decode log event %q: %w", event, err)
+	}
+	for key, value := range redacted {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		fields[key] = data
+	}
+	return json.Marshal(fields)
+}
+
+// observeLog returns the log events as seen by the given seat under the policy.
+func observeLog(events [][]byte, seat int, v core.Visibility) ([][]byte, error) {
+	if v == core.VisibilityFull {
+		return events, nil
+	}
+	observed := make([][]byte, len(events))
+	for i, event := range events {
+		var err error
+		if observed[i], err = observeLogEvent(event, seat, v); err != nil {
+			return nil, err
+		}
+	}
+	return observed, nil
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/WillMorrison/JouleQuestCardGame/assets"
+	"github.com/WillMorrison/JouleQuestCardGame/core"
+	"github.com/WillMorrison/JouleQuestCardGame/engine"
+)
+
+type observedGameResponse struct {
+	ID         string
+	SeatTokens []string
+	Game       struct {
+		Players    []engine.PlayerState
+		Visibility string
+	}
+}
+
+func Test_server_newGame_Visibility(t *testing.T) {
+	s, err := newServer(newMemoryStore(), 0)
+	if err != nil {
+		t.Fatalf("newServer() error = %v", err)
+	}
+	var created observedGameResponse
+	doRequest(t, s.Mux(), "POST", "/new?numPlayers=2&seatTokens=true&visibility=HiddenMoney", "", &created)
+
+	var seat1, spectator observedGameResponse
+	doSeatRequest(t, s.Mux(), "GET", "/g/"+created.ID, "", created.SeatTokens[1], &seat1)
+	doRequest(t, s.Mux(), "GET", "/g/"+created.ID, "", &spectator)
+
+	if seat1.Game.Visibility != "HiddenMoney" {
+		t.Errorf("Got visibility %q, want HiddenMoney", seat1.Game.Visibility)
+	}
+	if seat1.Game.Players[0].Money != 0 || seat1.Game.Players[1].Money == 0 {
+		t.Errorf("Seat 1 sees money %d and %d, want only its own", seat1.Game.Players[0].Money, seat1.Game.Players[1].Money)
+	}
+	for pi, p := range spectator.Game.Players {
+		if p.Money != 0 {
+			t.Errorf("Spectator sees player %d's money %d", pi, p.Money)
+		}
+	}
+}
+
+func Test_server_newGame_InvalidVisibility(t *testing.T) {
+	s, err := newServer(newMemoryStore(), 0)
+	if err != nil {
+		t.Fatalf("newServer() error = %v", err)
+	}
+
+	rec := doRequest(t, s.Mux(), "POST", "/new?numPlayers=2&visibility=Nope", "", nil)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Got status %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func Test_observePlayers_BucketedMix(t *testing.T) {
+	players := []engine.PlayerState{
+		{Money: 5, Assets: assets.AssetMix{Renewables: 2, FossilsWholesale: 4}},
+		{Money: 6, Assets: assets.AssetMix{Renewables: 2, FossilsWholesale: 7}},
+	}
+
+	got := observePlayers(players, 0, core.VisibilityBucketedMix)
+
+	if got[0].Assets != players[0].Assets {
+		t.Errorf("Own assets %+v, want %+v", got[0].Assets, players[0].Assets)
+	}
+	if want := (assets.AssetMix{Renewables: 1, FossilsWholesale: 6}); got[1].Assets != want {
+		t.Errorf("Opponent assets %+v, want %+v", got[1].Assets, want)
+	}
+	if got[1].Money != 6 {
+		t.Errorf("Opponent money %d, want 6", got[1].Money)
+	}
+}
+
+func Test_observePlayers_Full(t *testing.T) {
+	players := []engine.PlayerState{
+		{Money: 5, Assets: assets.AssetMix{Renewables: 2, FossilsWholesale: 4}},
+		{Money: 6, Assets: assets.AssetMix{Renewables: 2, FossilsWholesale: 7}},
+	}
+
+	got, err := json.Marshal(observePlayers(players, noSeat, core.VisibilityFull))
+
+	if want, _ := json.Marshal(players); err != nil || string(got) != string(want) {
+		t.Errorf("Players under full visibility = %s, %v, want %s", got, err, want)
+	}
+}
+
+// marketOutcomeMoney returns the player_money of each MarketOutcome event in the JSONL log, by player index.
+func marketOutcomeMoney(t *testing.T, lines []string) map[int][]int {
+	t.Helper()
+	money := map[int][]int{}
+	for _, line := range lines {
+		var e struct {
+			GameEvent   string `json:"game_event"`
+			PlayerIndex int    `json:"player_index"`
+			Money       int    `json:"player_money"`
+			PnL         int    `json:"player_PnL"`
+		}
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("Cannot decode log event %q: %s", line, err)
+		}
+		if e.GameEvent == engine.GameLogEventMarketOutcome.String() {
+			money[e.PlayerIndex] = append(money[e.PlayerIndex], e.Money, e.PnL)
+		}
+	}
+	return money
+}
+
+func Test_server_log_HidesOpponentMoney(t *testing.T) {
+	// Arrange: both seats finish the first build phase, so that the market outcomes are logged
+	s, err := newServer(newMemoryStore(), 0)
+	if err != nil {
+		t.Fatalf("newServer() error = %v", err)
+	}
+	ts := httptest.NewServer(s.Mux())
+	t.Cleanup(ts.Close)
+	var created observedGameResponse
+	doRequest(t, s.Mux(), "POST", "/new?numPlayers=2&seed=1&seatTokens=true&visibility=HiddenMoney", "", &created)
+	for pi, token := range created.SeatTokens {
+		body, _ := json.Marshal(engine.PlayerAction{Type: engine.ActionTypeFinished, PlayerIndex: pi})
+		doSeatRequest(t, s.Mux(), "POST", "/g/"+created.ID+"/action", string(body), token, nil)
+	}
+
+	// Act
+	seatLog := doSeatRequest(t, s.Mux(), "GET", "/g/"+created.ID+"/log", "", created.SeatTokens[1], nil).Body.String()
+	streamed, _ := readUntilState(t, openEventStream(t, ts, created.ID, ""))
+
+	// Assert
+	seen := marketOutcomeMoney(t, strings.Split(strings.TrimSpace(seatLog), "\n"))
+	if !slices.Equal(seen[0], []int{0, 0}) || len(seen[1]) != 2 || seen[1][0] == 0 {
+		t.Errorf("Seat 1 sees market outcome money and PnL %v, want only its own", seen)
+	}
+	var streamedLines []string
+	for _, e := range streamed {
+		streamedLines = append(streamedLines, e.Data)
+	}
+	seen = marketOutcomeMoney(t, streamedLines)
+	if len(seen) != 2 || !slices.Equal(seen[0], []int{0, 0}) || !slices.Equal(seen[1], []int{0, 0}) {
+		t.Errorf("Spectator stream has market outcome money and PnL %v, want all hidden", seen)
+	}
+}
+
+// playBuildRound has each seat of a new game build an asset and finish, and returns the rewards in the response to the
+// last action, which runs the operate phase.
+func playBuildRound(t *testing.T, visibility string) []int {
+	t.Helper()
+	s, err := newServer(newMemoryStore(), 0)
+	if err != nil {
+		t.Fatalf("newServer() error = %v", err)
+	}
+	var created observedGameResponse
+	doRequest(t, s.Mux(), "POST", "/new?numPlayers=2&seed=1&seatTokens=true&visibility="+visibility, "", &created)
+	act := func(pa engine.PlayerAction, token string) []int {
+		var resp struct{ Rewards rewardsResponse }
+		body, _ := json.Marshal(pa)
+		if rec := doSeatRequest(t, s.Mux(), "POST", "/g/"+created.ID+"/action", string(body), token, &resp); rec.Code != http.StatusOK {
+			t.Fatalf("Action %+v got status %d: %s", pa, rec.Code, rec.Body.String())
+		}
+		return resp.Rewards.MoneyDelta
+	}
+	for _, token := range created.SeatTokens {
+		var state testGameResponse
+		doSeatRequest(t, s.Mux(), "GET", "/g/"+created.ID, "", token, &state)
+		i := slices.IndexFunc(state.PossibleActions, func(pa engine.PlayerAction) bool { return pa.Type == engine.ActionTypeBuildAsset })
+		act(state.PossibleActions[i], token)
+	}
+	act(engine.PlayerAction{Type: engine.ActionTypeFinished, PlayerIndex: 0}, created.SeatTokens[0])
+	return act(engine.PlayerAction{Type: engine.ActionTypeFinished, PlayerIndex: 1}, created.SeatTokens[1])
+}
+
+func Test_server_action_HidesOpponentMoneyDelta(t *testing.T) {
+	full := playBuildRound(t, "Full")
+	hidden := playBuildRound(t, "HiddenMoney")
+
+	if full[0] == 0 || full[1] == 0 {
+		t.Fatalf("Got money deltas %v under full visibility, want both players' PnL", full)
+	}
+	if want := []int{0, full[1]}; !slices.Equal(hidden, want) {
+		t.Errorf("Seat 1 sees money deltas %v, want %v", hidden, want)
+	}
+}
+
+func Test_observeLogEvent(t *testing.T) {
+	tests := []struct {
+		name  string
+		event string
+		seat  int
+		v     core.Visibility
+		want  string
+	}{
+		{
+			name:  "full",
+			event: `{"game_event":"MarketOutcome","player_index":0,"player_money":5}`,
+			seat:  1,
+			v:     core.VisibilityFull,
+			want:  `{"game_event":"MarketOutcome","player_index":0,"player_money":5}`,
+		},
+		{
+			name:  "own money",
+			event: `{"game_event":"MarketOutcome","player_index":1,"player_money":5}`,
+			seat:  1,
+			v:     core.VisibilityHiddenMoney,
+			want:  `{"game_event":"MarketOutcome","player_index":1,"player_money":5}`,
+		},
+		{
+			name:  "opponent mix",
+			event: `{"player_asset_mix":{"Renewables":2},"player_index":0}`,
+			seat:  1,
+			v:     core.VisibilityBucketedMix,
+			want:  `{"player_asset_mix":{"Renewables":1,"BatteriesArbitrage":0,"BatteriesCapacity":0,"FossilsWholesale":0,"FossilsCapacity":0},"player_index":0}`,
+		},
+		{
+			name:  "player funds",
+			event: `{"loss_reason":"UnownedTakeoverAssets","player_funds":[3,4]}`,
+			seat:  1,
+			v:     core.VisibilityHiddenMoney,
+			want:  `{"loss_reason":"UnownedTakeoverAssets","player_funds":[0,4]}`,
+		},
+		{
+			name:  "game end players",
+			event: `{"players":[{"Status":"Active","Money":3,"Assets":{"Renewables":2}}]}`,
+			seat:  noSeat,
+			v:     core.VisibilityHiddenMoney,
+			want:  `{"players":[{"Status":"Active","Money":0,"Assets":{"Renewables":2,"BatteriesArbitrage":0,"BatteriesCapacity":0,"FossilsWholesale":0,"FossilsCapacity":0}}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := observeLogEvent([]byte(tt.event), tt.seat, tt.v)
+
+			if err != nil || string(got) != tt.want {
+				t.Errorf("observeLogEvent() = %s, %v, want %s", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ var tmplSources embed.FS
 
 type tmplInput struct {
 	Exports []wasmcodegen.WasmExportedFunc
+	Enums   []wasmcodegen.Enum
 }
 
 func loadInput() (tmplInput, error) {
@@ -33,10 +34,19 @@ func loadInput() (tmplInput, error) {
 	}
 
 	var ti tmplInput
+	enumPkgs := make(map[string]string)
 	for _, pkg := range pkgs {
 		if pkg.PkgPath == wasmPkg {
 			ti.Exports = pkg.Exports
 		}
+		// Enums from all packages share one Python module, so their names must be unique
+		for _, e := range pkg.Enums {
+			if other, ok := enumPkgs[e.Name]; ok {
+				return tmplInput{}, fmt.Errorf("enum %s is defined in both %s and %s", e.Name, other, pkg.PkgPath)
+			}
+			enumPkgs[e.Name] = pkg.PkgPath
+			ti.Enums = append(ti.Enums, e)
+		}
 	}
 	if ti.Exports == nil {
 		return tmplInput{}, fmt.Errorf("no exported functions found")
@@ -84,6 +94,9 @@ func main() {
 	if err := generate(templates, input, "_client.py.tmpl", path.Join(*outDir, "_client.py")); err != nil {
 		log.Fatalf("Generate error: %s", err)
 	}
+	if err := generate(templates, input, "_enums.py.tmpl", path.Join(*outDir, "_enums.py")); err != nil {
+		log.Fatalf("Generate error: %s", err)
+	}
 	if err := generate(templates, input, "__init__.py.tmpl", path.Join(*outDir, "__init__.py")); err != nil {
 		log.Fatalf("Generate error: %s", err)
 	}
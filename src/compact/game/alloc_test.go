@@ -52,3 +52,28 @@ func Benchmark_Game_ApplyPlayerAction(b *testing.B) {
 	b.ReportMetric(float64(resets), "games")
 	b.ReportMetric(float64(applications), "actions")
 }
+
+func Test_Game_CloneCopyFrom_NoAllocs(t *testing.T) {
+	var g, saved Game
+	g.Reset(4, params.Default)
+	allocs := testing.AllocsPerRun(100, func() {
+		saved.CopyFrom(&g)
+		c := g.Clone()
+		g.CopyFrom(&c)
+	})
+	if allocs != 0 {
+		t.Errorf("Clone/CopyFrom allocated %v times per run, want 0", allocs)
+	}
+}
+
+func Test_Game_EncodeObservation_NoAllocs(t *testing.T) {
+	var g Game
+	g.Reset(4, params.Default)
+	var buf [ObsSize]int32
+	allocs := testing.AllocsPerRun(100, func() {
+		g.EncodeObservation(1, core.VisibilityBucketedMix, buf[:])
+	})
+	if allocs != 0 {
+		t.Errorf("EncodeObservation allocated %v times per run, want 0", allocs)
+	}
+}
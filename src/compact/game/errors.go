@@ -1,5 +1,6 @@
 package game
 
+//pybindgen:enum Code
 type ErrCode int32
 
 const (
@@ -7,6 +8,9 @@ const (
 	CodeInvalidPlayerCount
 	CodeInvalidAction
 	CodeUnknown
+	CodeInvalidParam
+	CodeInvalidSlot
+	CodeNothingToUndo
 )
 
 func (ec ErrCode) Error() string {
@@ -17,6 +21,12 @@ func (ec ErrCode) Error() string {
 		return "invalid player num"
 	case CodeInvalidAction:
 		return "invalid action"
+	case CodeInvalidParam:
+		return "invalid param"
+	case CodeInvalidSlot:
+		return "invalid slot"
+	case CodeNothingToUndo:
+		return "nothing to undo"
 	default:
 		return "unknown error"
 	}
@@ -33,6 +33,10 @@ type Game struct {
 	Params          cparams.CompactParams
 	// PCG RNG for operate-phase randomness
 	pcg randv2.PCG
+	// Actions applied in the current build phase, for Undo
+	undo undoHistory
+	// State before the last applied action, for rewards
+	prev transition
 }
 
 // NewGame constructs a game in the first build phase (same entry behavior as engine.NewProceduralGame).
@@ -45,15 +49,21 @@ func NewGame(numPlayers int32, p cparams.CompactParams) (*Game, error) {
 	return &g, nil
 }
 
+// CheckPlayerCount returns CodeInvalidPlayerCount if a game with the parameters cannot have numPlayers players, so that
+// callers can validate before resetting anything.
+func CheckPlayerCount(numPlayers int32, p cparams.CompactParams) ErrCode {
+	if numPlayers < 2 || numPlayers > cparams.MaxPlayers || p.StartingFossils(numPlayers) <= 0 {
+		return CodeInvalidPlayerCount
+	}
+	return CodeOK
+}
+
 // Reset resets the game to its initial state. The RNG is not reset. Returns an error code
 func (g *Game) Reset(numPlayers int32, p cparams.CompactParams) ErrCode {
-	if numPlayers < 2 || numPlayers > cparams.MaxPlayers {
-		return CodeInvalidPlayerCount
+	if code := CheckPlayerCount(numPlayers, p); code != CodeOK {
+		return code
 	}
 	startingFossils := p.StartingFossils(numPlayers)
-	if startingFossils <= 0 {
-		return CodeInvalidPlayerCount
-	}
 
 	g.Params = p
 
@@ -83,11 +93,24 @@ func (g *Game) Reset(numPlayers int32, p cparams.CompactParams) ErrCode {
 	}
 	g.LastSnapshot = snapshotFromGlobalMix(g.globalAssetMix())
 	g.startBuildPhase()
+	g.recordTransitionStart()
 	return CodeOK
 }
 
+// Clone returns an independent copy of the game, including its RNG state. Game holds no pointers, so this does not
+// allocate when the copy is kept on the stack or in preallocated storage.
+func (g *Game) Clone() Game {
+	return *g
+}
+
+// CopyFrom overwrites the game with a copy of src, including its RNG state, without allocating.
+func (g *Game) CopyFrom(src *Game) {
+	*g = *src
+}
+
 func (g *Game) startBuildPhase() {
 	g.phase = phaseBuild
+	g.undo.clear()
 	for i := int32(0); i < g.NumPlayers; i++ {
 		if g.Players[i].Status == core.PlayerStatusActive {
 			g.Players[i].IsBuilding = true
@@ -138,6 +161,13 @@ func (g *Game) ApplyPlayerAction(playerIndex int32, actionCode int32) ErrCode {
 	if !actionCodeAllowed(mask, actionCode) {
 		return CodeInvalidAction
 	}
+	g.recordTransitionStart()
+	g.undo.push(undoEntry{
+		playerIndex:  playerIndex,
+		actionCode:   actionCode,
+		player:       g.Players[playerIndex],
+		takeoverPool: g.TakeoverPool,
+	})
 	g.applyActionCode(playerIndex, actionCode)
 
 	if !g.anyPlayerHasPossibleActions() {
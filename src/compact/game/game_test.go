@@ -156,3 +156,33 @@ func TestReset(t *testing.T) {
 		}
 	}
 }
+
+func TestCloneIsIndependentAndReplaysRNG(t *testing.T) {
+	g, _ := NewGame(2, cparams.Default)
+	g.SetRNGSeed(7)
+	fork := g.Clone()
+
+	// Play the original through an operate phase
+	if err := g.ApplyPlayerAction(0, ActionBuildRenewable); err != CodeOK {
+		t.Fatal(err)
+	}
+	g.ApplyPlayerAction(0, ActionFinished)
+	g.ApplyPlayerAction(1, ActionFinished)
+	if fork.Round != 1 || fork.Players[0].Mix.Renewables != 0 {
+		t.Fatalf("fork changed with original: round %d, mix %+v", fork.Round, fork.Players[0].Mix)
+	}
+
+	// The same actions from the fork reach the same state, since the RNG state was copied too
+	fork.ApplyPlayerAction(0, ActionBuildRenewable)
+	fork.ApplyPlayerAction(0, ActionFinished)
+	fork.ApplyPlayerAction(1, ActionFinished)
+	if fork != *g {
+		t.Fatalf("fork diverged:\ngot  %+v\nwant %+v", fork, *g)
+	}
+
+	var restored Game
+	restored.CopyFrom(g)
+	if restored != *g {
+		t.Fatalf("CopyFrom: got %+v, want %+v", restored, *g)
+	}
+}
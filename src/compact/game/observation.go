@@ -0,0 +1,185 @@
+package game
+
+import (
+	"github.com/WillMorrison/JouleQuestCardGame/assets"
+	cparams "github.com/WillMorrison/JouleQuestCardGame/compact/params"
+	"github.com/WillMorrison/JouleQuestCardGame/core"
+)
+
+// ObservationVersion is written to ObsVersion, and is incremented whenever the observation layout changes.
+const ObservationVersion = 1
+
+// ObsField is an index into an observation written by EncodeObservation.
+//
+// Version 1 layout:
+//   - header: version, game status, game reason, phase, round, carbon emissions, number of players
+//   - last snapshot: asset mix, grid stability, price volatility
+//   - takeover pool asset mix
+//   - own state: status, loss reason, money, whether still building, asset mix
+//   - opponents: MaxPlayers-1 blocks of ObsOpponentSize values, see ObsOpponentField
+//
+// Opponents' money and asset mixes are filtered by the core.Visibility policy, with hidden values written as 0. The
+// layout does not depend on the policy.
+//
+// Asset mixes are always 5 values in the order renewables, batteries arbitrage, batteries capacity, fossils
+// wholesale, fossils capacity.
+//
+//pybindgen:enum Obs
+type ObsField int32
+
+const (
+	ObsVersion ObsField = iota
+	ObsGameStatus
+	ObsGameReason
+	ObsPhase
+	ObsRound
+	ObsCarbonEmissions
+	ObsNumPlayers
+	ObsSnapshotRenewables
+	ObsSnapshotBatteriesArbitrage
+	ObsSnapshotBatteriesCapacity
+	ObsSnapshotFossilsWholesale
+	ObsSnapshotFossilsCapacity
+	ObsSnapshotGridStability
+	ObsSnapshotPriceVolatility
+	ObsTakeoverRenewables
+	ObsTakeoverBatteriesArbitrage
+	ObsTakeoverBatteriesCapacity
+	ObsTakeoverFossilsWholesale
+	ObsTakeoverFossilsCapacity
+	ObsOwnStatus
+	ObsOwnReason
+	ObsOwnMoney
+	ObsOwnBuilding
+	ObsOwnRenewables
+	ObsOwnBatteriesArbitrage
+	ObsOwnBatteriesCapacity
+	ObsOwnFossilsWholesale
+	ObsOwnFossilsCapacity
+	ObsOpponents                                                                   // Start of the first opponent block
+	ObsSize      = ObsOpponents + (cparams.MaxPlayers-1)*ObsField(ObsOpponentSize) // Total observation length
+)
+
+// ObsOpponentField is an offset into one opponent block of an observation. Opponents are ordered by seat, starting
+// with the player after the observing player. Blocks beyond the number of opponents are zero, except for the status,
+// which is lost.
+//
+//pybindgen:enum ObsOpponent
+type ObsOpponentField int32
+
+const (
+	ObsOpponentStatus ObsOpponentField = iota
+	ObsOpponentMoney
+	ObsOpponentBuilding
+	ObsOpponentRenewables
+	ObsOpponentBatteriesArbitrage
+	ObsOpponentBatteriesCapacity
+	ObsOpponentFossilsWholesale
+	ObsOpponentFossilsCapacity
+	ObsOpponentSize // Length of an opponent block
+)
+
+func boolToInt32(b bool) int32 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func encodeAssetMix(am assets.AssetMix, buf []int32) {
+	buf[0] = int32(am.Renewables)
+	buf[1] = int32(am.BatteriesArbitrage)
+	buf[2] = int32(am.BatteriesCapacity)
+	buf[3] = int32(am.FossilsWholesale)
+	buf[4] = int32(am.FossilsCapacity)
+}
+
+// NoObserver is the observer index of spectators, who see every player as an opponent.
+const NoObserver int32 = -1
+
+func observedMoney(p *Player, v core.Visibility) int32 {
+	if v.HidesOpponentMoney() {
+		return 0
+	}
+	return p.Money
+}
+
+func observedMix(am assets.AssetMix, v core.Visibility) assets.AssetMix {
+	return assets.AssetMix{
+		Renewables:         v.OpponentAssetCount(am.Renewables),
+		BatteriesArbitrage: v.OpponentAssetCount(am.BatteriesArbitrage),
+		BatteriesCapacity:  v.OpponentAssetCount(am.BatteriesCapacity),
+		FossilsWholesale:   v.OpponentAssetCount(am.FossilsWholesale),
+		FossilsCapacity:    v.OpponentAssetCount(am.FossilsCapacity),
+	}
+}
+
+// ObservedPlayerMoney returns player pi's money as seen by the observer under the visibility policy, or 0 if it is
+// hidden. Players always see their own money.
+func (g *Game) ObservedPlayerMoney(observer, pi int32, v core.Visibility) int32 {
+	if pi < 0 || pi >= g.NumPlayers {
+		return 0
+	}
+	if pi == observer {
+		return g.Players[pi].Money
+	}
+	return observedMoney(&g.Players[pi], v)
+}
+
+// ObservedPlayerAssetMix returns player pi's asset mix as seen by the observer under the visibility policy. Players
+// always see their own assets.
+func (g *Game) ObservedPlayerAssetMix(observer, pi int32, v core.Visibility) assets.AssetMix {
+	if pi < 0 || pi >= g.NumPlayers {
+		return assets.AssetMix{}
+	}
+	if pi == observer {
+		return g.Players[pi].Mix
+	}
+	return observedMix(g.Players[pi].Mix, v)
+}
+
+// EncodeObservation writes player pi's view of the game under the visibility policy into buf, using the layout
+// described by ObsField. buf must hold at least ObsSize values. It does not allocate.
+func (g *Game) EncodeObservation(pi int32, v core.Visibility, buf []int32) ErrCode {
+	if pi < 0 || pi >= g.NumPlayers {
+		return CodeInvalidParam
+	}
+	if len(buf) < int(ObsSize) {
+		return CodeInvalidParam
+	}
+	buf = buf[:ObsSize]
+
+	buf[ObsVersion] = ObservationVersion
+	buf[ObsGameStatus] = int32(g.Status)
+	buf[ObsGameReason] = int32(g.Reason)
+	buf[ObsPhase] = int32(g.phase)
+	buf[ObsRound] = g.Round
+	buf[ObsCarbonEmissions] = g.CarbonEmissions
+	buf[ObsNumPlayers] = g.NumPlayers
+	encodeAssetMix(g.LastSnapshot.AssetMix, buf[ObsSnapshotRenewables:])
+	buf[ObsSnapshotGridStability] = int32(g.LastSnapshot.GridStability)
+	buf[ObsSnapshotPriceVolatility] = int32(g.LastSnapshot.PriceVolatility)
+	encodeAssetMix(g.TakeoverPool, buf[ObsTakeoverRenewables:])
+
+	own := &g.Players[pi]
+	buf[ObsOwnStatus] = int32(own.Status)
+	buf[ObsOwnReason] = int32(own.Reason)
+	buf[ObsOwnMoney] = own.Money
+	buf[ObsOwnBuilding] = boolToInt32(own.IsBuilding)
+	encodeAssetMix(own.Mix, buf[ObsOwnRenewables:])
+
+	for i := int32(1); i < cparams.MaxPlayers; i++ {
+		block := buf[ObsOpponents+ObsField(i-1)*ObsField(ObsOpponentSize):][:ObsOpponentSize]
+		clear(block)
+		if i >= g.NumPlayers {
+			block[ObsOpponentStatus] = int32(core.PlayerStatusLost)
+			continue
+		}
+		opp := &g.Players[(pi+i)%g.NumPlayers]
+		block[ObsOpponentStatus] = int32(opp.Status)
+		block[ObsOpponentMoney] = observedMoney(opp, v)
+		block[ObsOpponentBuilding] = boolToInt32(opp.IsBuilding)
+		encodeAssetMix(observedMix(opp.Mix, v), block[ObsOpponentRenewables:])
+	}
+	return CodeOK
+}
@@ -0,0 +1,100 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/WillMorrison/JouleQuestCardGame/assets"
+	cparams "github.com/WillMorrison/JouleQuestCardGame/compact/params"
+	"github.com/WillMorrison/JouleQuestCardGame/core"
+)
+
+func TestEncodeObservationLayout(t *testing.T) {
+	g, _ := NewGame(3, cparams.Default)
+	g.Players[0].Money = 10
+	g.Players[1].Money = 11
+	g.Players[2].Money = 12
+	g.Players[2].Mix = assets.AssetMix{Renewables: 1, BatteriesArbitrage: 2, BatteriesCapacity: 3, FossilsWholesale: 4, FossilsCapacity: 5}
+	g.TakeoverPool = assets.AssetMix{FossilsCapacity: 6}
+	g.CarbonEmissions = 7
+	buf := make([]int32, ObsSize)
+
+	if err := g.EncodeObservation(1, core.VisibilityFull, buf); err != CodeOK {
+		t.Fatal(err)
+	}
+
+	checks := []struct {
+		name  string
+		index int
+		want  int32
+	}{
+		{"version", int(ObsVersion), ObservationVersion},
+		{"phase", int(ObsPhase), int32(phaseBuild)},
+		{"round", int(ObsRound), 1},
+		{"emissions", int(ObsCarbonEmissions), 7},
+		{"num players", int(ObsNumPlayers), 3},
+		{"takeover fossils capacity", int(ObsTakeoverFossilsCapacity), 6},
+		{"own money", int(ObsOwnMoney), 11},
+		{"own building", int(ObsOwnBuilding), 1},
+		{"own fossils", int(ObsOwnFossilsWholesale), 7},
+		{"first opponent money", int(ObsOpponents) + int(ObsOpponentMoney), 12},
+		{"first opponent batteries capacity", int(ObsOpponents) + int(ObsOpponentBatteriesCapacity), 3},
+		{"second opponent money", int(ObsOpponents+ObsField(ObsOpponentSize)) + int(ObsOpponentMoney), 10},
+		{"third opponent status", int(ObsOpponents+2*ObsField(ObsOpponentSize)) + int(ObsOpponentStatus), int32(core.PlayerStatusLost)},
+		{"third opponent money", int(ObsOpponents+2*ObsField(ObsOpponentSize)) + int(ObsOpponentMoney), 0},
+	}
+	for _, c := range checks {
+		if buf[c.index] != c.want {
+			t.Errorf("%s: buf[%d] = %d, want %d", c.name, c.index, buf[c.index], c.want)
+		}
+	}
+}
+
+func TestEncodeObservationErrors(t *testing.T) {
+	g, _ := NewGame(2, cparams.Default)
+	if err := g.EncodeObservation(0, core.VisibilityFull, make([]int32, ObsSize-1)); err != CodeInvalidParam {
+		t.Errorf("short buffer: %v", err)
+	}
+	if err := g.EncodeObservation(2, core.VisibilityFull, make([]int32, ObsSize)); err != CodeInvalidParam {
+		t.Errorf("invalid player: %v", err)
+	}
+}
+
+func TestEncodeObservationVisibility(t *testing.T) {
+	g, _ := NewGame(2, cparams.Default)
+	g.Players[0].Money = 10
+	g.Players[1].Money = 11
+	g.Players[0].Mix = assets.AssetMix{Renewables: 2, FossilsWholesale: 4}
+	g.Players[1].Mix = assets.AssetMix{Renewables: 2, FossilsWholesale: 7}
+	opponent := int(ObsOpponents)
+
+	tests := []struct {
+		v                                        core.Visibility
+		wantOppMoney, wantOppRen, wantOppFossils int32
+	}{
+		{core.VisibilityFull, 11, 2, 7},
+		{core.VisibilityHiddenMoney, 0, 2, 7},
+		{core.VisibilityBucketedMix, 11, 1, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.v.String(), func(t *testing.T) {
+			buf := make([]int32, ObsSize)
+			if err := g.EncodeObservation(0, tt.v, buf); err != CodeOK {
+				t.Fatal(err)
+			}
+			if buf[ObsOwnMoney] != 10 || buf[ObsOwnRenewables] != 2 || buf[ObsOwnFossilsWholesale] != 4 {
+				t.Errorf("own state filtered: money %d, renewables %d, fossils %d", buf[ObsOwnMoney], buf[ObsOwnRenewables], buf[ObsOwnFossilsWholesale])
+			}
+			got := [3]int32{buf[opponent+int(ObsOpponentMoney)], buf[opponent+int(ObsOpponentRenewables)], buf[opponent+int(ObsOpponentFossilsWholesale)]}
+			want := [3]int32{tt.wantOppMoney, tt.wantOppRen, tt.wantOppFossils}
+			if got != want {
+				t.Errorf("opponent money, renewables, fossils = %v, want %v", got, want)
+			}
+			if money := g.ObservedPlayerMoney(0, 1, tt.v); money != tt.wantOppMoney {
+				t.Errorf("ObservedPlayerMoney = %d, want %d", money, tt.wantOppMoney)
+			}
+			if mix := g.ObservedPlayerAssetMix(NoObserver, 1, tt.v); int32(mix.FossilsWholesale) != tt.wantOppFossils {
+				t.Errorf("ObservedPlayerAssetMix for a spectator has %d fossils, want %d", mix.FossilsWholesale, tt.wantOppFossils)
+			}
+		})
+	}
+}
@@ -126,6 +126,14 @@ func checkParity(t *testing.T, step int, pgs *engine.ProceduralGameState, cg *ga
 		if legacyMask != compactMask {
 			t.Errorf("step %d: player %d PossibleActionMask mismatch: legacy=%b, compact=%b", step, i, legacyMask, compactMask)
 		}
+
+		for _, scheme := range []core.RewardScheme{core.RewardSchemeTerminal, core.RewardSchemeMoneyDelta, core.RewardSchemeShaped} {
+			legacyReward := pgs.Reward(int(i), scheme)
+			compactReward := cg.Reward(i, scheme)
+			if int32(legacyReward) != compactReward {
+				t.Errorf("step %d: player %d %v reward mismatch: legacy=%d, compact=%d", step, i, scheme, legacyReward, compactReward)
+			}
+		}
 	}
 
 	// Takeover pool mix
@@ -279,3 +287,77 @@ func TestParity_Stress(t *testing.T) {
 		checkParity(t, step, pgs, cg)
 	}
 }
+
+func TestParity_Undo(t *testing.T) {
+	legacyParams := params.Default
+	compactParams, _ := cparams.FromLegacy(legacyParams)
+	pgs, err := engine.NewProceduralGame(2, legacyParams, eventlog.NullLogger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cg, err := game.NewGame(2, compactParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []actionStep{
+		{0, game.ActionBuildBattery},
+		{1, game.ActionScrapFossil},
+		{0, game.ActionPledgeBattery},
+		{1, game.ActionFinished},
+	}
+	for i, step := range steps {
+		pgs.ApplyPlayerAction(actionCodeToLegacy(step.playerIndex, step.actionCode, legacyParams))
+		if err := cg.ApplyPlayerAction(int32(step.playerIndex), step.actionCode); err != game.CodeOK {
+			t.Fatalf("step %d: %v", i, err.Error())
+		}
+	}
+	for i := range steps {
+		if _, err := pgs.Undo(); err != nil {
+			t.Fatalf("undo %d: legacy: %v", i, err)
+		}
+		if err := cg.Undo(); err != game.CodeOK {
+			t.Fatalf("undo %d: compact: %v", i, err.Error())
+		}
+		checkParity(t, i, pgs, cg)
+	}
+}
+
+func TestParity_UndoLimit(t *testing.T) {
+	legacyParams := params.Default
+	legacyParams.InitialCash = 1000
+	compactParams, _ := cparams.FromLegacy(legacyParams)
+	pgs, err := engine.NewProceduralGame(2, legacyParams, eventlog.NullLogger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cg, err := game.NewGame(2, compactParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range game.MaxUndo + 2 {
+		code := int32(game.ActionBuildRenewable)
+		if i%2 == 1 {
+			code = game.ActionScrapRenewable
+		}
+		pgs.ApplyPlayerAction(actionCodeToLegacy(0, code, legacyParams))
+		if err := cg.ApplyPlayerAction(0, code); err != game.CodeOK {
+			t.Fatalf("step %d: %v", i, err.Error())
+		}
+	}
+	for i := 0; ; i++ {
+		_, legacyErr := pgs.Undo()
+		compactErr := cg.Undo()
+		if (legacyErr == nil) != (compactErr == game.CodeOK) {
+			t.Fatalf("undo %d: legacy error %v, compact error %v", i, legacyErr, compactErr.Error())
+		}
+		if legacyErr != nil {
+			if i != game.MaxUndo {
+				t.Fatalf("Undid %d actions, want %d", i, game.MaxUndo)
+			}
+			break
+		}
+		checkParity(t, i, pgs, cg)
+	}
+}
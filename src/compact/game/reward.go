@@ -0,0 +1,67 @@
+package game
+
+import (
+	cparams "github.com/WillMorrison/JouleQuestCardGame/compact/params"
+	"github.com/WillMorrison/JouleQuestCardGame/core"
+)
+
+// transition records the parts of the game state before the last applied action that rewards depend on.
+type transition struct {
+	status      core.GameStatus
+	emissions   int32
+	penetration int32
+	players     [cparams.MaxPlayers]struct {
+		status core.PlayerStatus
+		money  int32
+	}
+}
+
+// recordTransitionStart saves the current state as the start of the next transition.
+func (g *Game) recordTransitionStart() {
+	t := &g.prev
+	t.status = g.Status
+	t.emissions = g.CarbonEmissions
+	t.penetration = int32(g.LastSnapshot.AssetMix.RenewablePenetration())
+	for i := range t.players {
+		t.players[i].status = g.Players[i].Status
+		t.players[i].money = g.Players[i].Money
+	}
+}
+
+func (g *Game) terminalReward(pi int32) int32 {
+	if g.prev.players[pi].status != core.PlayerStatusActive {
+		return 0
+	}
+	switch {
+	case g.Status == core.GameStatusLoss && g.prev.status == core.GameStatusOngoing:
+		return -1
+	case g.Players[pi].Status == core.PlayerStatusLost:
+		return -1
+	case g.Status == core.GameStatusWin && g.prev.status == core.GameStatusOngoing:
+		return 1
+	}
+	return 0
+}
+
+// Reward returns the reward for player pi for the last applied action, including any operate phase it triggered,
+// under the given scheme. Matches engine.TransitionReward.
+func (g *Game) Reward(pi int32, scheme core.RewardScheme) int32 {
+	if pi < 0 || pi >= g.NumPlayers {
+		return 0
+	}
+	switch scheme {
+	case core.RewardSchemeTerminal:
+		return g.terminalReward(pi)
+	case core.RewardSchemeMoneyDelta:
+		return g.Players[pi].Money - g.prev.players[pi].money
+	case core.RewardSchemeShaped:
+		if g.prev.players[pi].status != core.PlayerStatusActive {
+			return 0
+		}
+		penetration := int32(g.LastSnapshot.AssetMix.RenewablePenetration()) - g.prev.penetration
+		emissions := g.CarbonEmissions - g.prev.emissions
+		return core.ShapedTerminalWeight*g.terminalReward(pi) + penetration - emissions
+	default:
+		return 0
+	}
+}
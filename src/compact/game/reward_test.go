@@ -0,0 +1,51 @@
+package game
+
+import (
+	"testing"
+
+	cparams "github.com/WillMorrison/JouleQuestCardGame/compact/params"
+	"github.com/WillMorrison/JouleQuestCardGame/core"
+)
+
+func TestRewardMoneyDelta(t *testing.T) {
+	g, _ := NewGame(2, cparams.Default)
+	if r := g.Reward(0, core.RewardSchemeMoneyDelta); r != 0 {
+		t.Errorf("reward after reset = %d, want 0", r)
+	}
+
+	g.ApplyPlayerAction(0, ActionBuildRenewable)
+
+	if r := g.Reward(0, core.RewardSchemeMoneyDelta); r != -cparams.Default.RenewableBuildCost {
+		t.Errorf("player 0 reward = %d, want %d", r, -cparams.Default.RenewableBuildCost)
+	}
+	if r := g.Reward(1, core.RewardSchemeMoneyDelta); r != 0 {
+		t.Errorf("player 1 reward = %d, want 0", r)
+	}
+	if r := g.Reward(0, core.RewardSchemeTerminal); r != 0 {
+		t.Errorf("player 0 terminal reward = %d, want 0", r)
+	}
+}
+
+func TestRewardGlobalLoss(t *testing.T) {
+	p := cparams.Default
+	p.EmissionsCap = 1
+	g, _ := NewGame(2, p)
+	g.ApplyPlayerAction(0, ActionFinished)
+	g.ApplyPlayerAction(1, ActionFinished)
+	if g.Status != core.GameStatusLoss {
+		t.Fatalf("expected game to be lost, got %v", g.Status)
+	}
+
+	for pi := range int32(2) {
+		if r := g.Reward(pi, core.RewardSchemeTerminal); r != -1 {
+			t.Errorf("player %d terminal reward = %d, want -1", pi, r)
+		}
+		wantShaped := -core.ShapedTerminalWeight - g.CarbonEmissions
+		if r := g.Reward(pi, core.RewardSchemeShaped); r != wantShaped {
+			t.Errorf("player %d shaped reward = %d, want %d", pi, r, wantShaped)
+		}
+	}
+	if r := g.Reward(2, core.RewardSchemeTerminal); r != 0 {
+		t.Errorf("reward for missing player = %d, want 0", r)
+	}
+}
@@ -0,0 +1,65 @@
+package game
+
+import (
+	"github.com/WillMorrison/JouleQuestCardGame/assets"
+	"github.com/WillMorrison/JouleQuestCardGame/core"
+)
+
+// MaxUndo is the number of most recent build-phase actions that can be undone, the same as the legacy engine. The
+// history is fixed-size so that the game stays allocation-free and cheap to copy.
+const MaxUndo = core.MaxUndo
+
+// undoEntry records the state changed by an applied action, so that it can be undone.
+type undoEntry struct {
+	playerIndex  int32
+	actionCode   int32
+	player       Player // The acting player's state before the action
+	takeoverPool assets.AssetMix
+}
+
+// undoHistory is a ring buffer of the most recent actions in the current build phase.
+type undoHistory struct {
+	entries [MaxUndo]undoEntry
+	next    int32 // Index where the next entry will be written
+	len     int32 // Number of valid entries, at most MaxUndo
+}
+
+func (h *undoHistory) clear() {
+	*h = undoHistory{}
+}
+
+func (h *undoHistory) push(e undoEntry) {
+	h.entries[h.next] = e
+	h.next = (h.next + 1) % MaxUndo
+	if h.len < MaxUndo {
+		h.len++
+	}
+}
+
+func (h *undoHistory) pop() (undoEntry, bool) {
+	if h.len == 0 {
+		return undoEntry{}, false
+	}
+	h.next = (h.next + MaxUndo - 1) % MaxUndo
+	h.len--
+	e := h.entries[h.next]
+	h.entries[h.next] = undoEntry{}
+	return e, true
+}
+
+// Undo reverts the most recently applied action of the current build phase, restoring the acting player's money and
+// assets and the takeover pool. Up to MaxUndo actions can be undone in a row. Actions cannot be undone once the
+// operate phase has run, or after the game has ended.
+func (g *Game) Undo() ErrCode {
+	if g.phase != phaseBuild || g.Status != core.GameStatusOngoing {
+		return CodeNothingToUndo
+	}
+	e, ok := g.undo.pop()
+	if !ok {
+		return CodeNothingToUndo
+	}
+	g.Players[e.playerIndex] = e.player
+	g.TakeoverPool = e.takeoverPool
+	g.recordTransitionStart() // An undone action has no reward
+	return CodeOK
+}
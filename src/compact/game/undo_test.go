@@ -0,0 +1,65 @@
+package game
+
+import (
+	"testing"
+
+	cparams "github.com/WillMorrison/JouleQuestCardGame/compact/params"
+)
+
+func TestUndoRestoresState(t *testing.T) {
+	g, _ := NewGame(2, cparams.Default)
+	before := *g
+	g.ApplyPlayerAction(0, ActionBuildBattery)
+	g.ApplyPlayerAction(1, ActionScrapFossil)
+	g.ApplyPlayerAction(0, ActionPledgeBattery)
+	g.ApplyPlayerAction(0, ActionFinished)
+
+	for i := range 4 {
+		if err := g.Undo(); err != CodeOK {
+			t.Fatalf("Undo %d: %v", i, err)
+		}
+	}
+	if err := g.Undo(); err != CodeNothingToUndo {
+		t.Fatalf("Undo with empty history = %v, want %v", err, CodeNothingToUndo)
+	}
+	if *g != before {
+		t.Fatalf("Undo did not restore state:\ngot  %+v\nwant %+v", *g, before)
+	}
+}
+
+func TestUndoIsLimitedToMaxUndo(t *testing.T) {
+	p := cparams.Default
+	p.InitialCash = 1000
+	g, _ := NewGame(2, p)
+	for range MaxUndo + 2 {
+		g.ApplyPlayerAction(0, ActionBuildRenewable)
+		g.ApplyPlayerAction(0, ActionScrapRenewable)
+	}
+
+	var undone int
+	for g.Undo() == CodeOK {
+		undone++
+	}
+
+	if undone != MaxUndo {
+		t.Fatalf("Undid %d actions, want %d", undone, MaxUndo)
+	}
+}
+
+func TestUndoDoesNotCrossOperatePhase(t *testing.T) {
+	g, _ := NewGame(2, cparams.Default)
+	g.ApplyPlayerAction(0, ActionBuildRenewable)
+	g.ApplyPlayerAction(0, ActionFinished)
+	g.ApplyPlayerAction(1, ActionFinished)
+	if g.Round != 2 {
+		t.Fatalf("Expected operate phase to have run, round is %d", g.Round)
+	}
+	before := *g
+
+	if err := g.Undo(); err != CodeNothingToUndo {
+		t.Fatalf("Undo after operate phase = %v, want %v", err, CodeNothingToUndo)
+	}
+	if *g != before {
+		t.Fatalf("Failed Undo changed state")
+	}
+}
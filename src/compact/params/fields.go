@@ -0,0 +1,127 @@
+package params
+
+// Field identifies a scalar int32 field of CompactParams, for setting parameters through integer-only interfaces like WASM.
+//
+//pybindgen:enum
+type Field int32
+
+const (
+	FieldInitialCash Field = iota
+	FieldBatteryBuildCost
+	FieldBatteryScrapCost
+	FieldRenewableBuildCost
+	FieldRenewableScrapCost
+	FieldFossilBuildCost
+	FieldFossilScrapCost
+	FieldEmissionsCap
+	FieldGenerationConstraint
+	FieldCarbonTaxThreshold
+	FieldCarbonTaxCost
+	FieldRenewablePenetration
+)
+
+// field returns a pointer to the field identified by f, or nil if f is not a valid Field.
+func (c *CompactParams) field(f Field) *int32 {
+	switch f {
+	case FieldInitialCash:
+		return &c.InitialCash
+	case FieldBatteryBuildCost:
+		return &c.BatteryBuildCost
+	case FieldBatteryScrapCost:
+		return &c.BatteryScrapCost
+	case FieldRenewableBuildCost:
+		return &c.RenewableBuildCost
+	case FieldRenewableScrapCost:
+		return &c.RenewableScrapCost
+	case FieldFossilBuildCost:
+		return &c.FossilBuildCost
+	case FieldFossilScrapCost:
+		return &c.FossilScrapCost
+	case FieldEmissionsCap:
+		return &c.EmissionsCap
+	case FieldGenerationConstraint:
+		return &c.GenerationConstraint
+	case FieldCarbonTaxThreshold:
+		return &c.CarbonTaxThreshold
+	case FieldCarbonTaxCost:
+		return &c.CarbonTaxCost
+	case FieldRenewablePenetration:
+		return &c.RenewablePenetration
+	default:
+		return nil
+	}
+}
+
+// SetField sets the field identified by f. Returns false if f is not a valid Field.
+func (c *CompactParams) SetField(f Field, value int32) bool {
+	p := c.field(f)
+	if p == nil {
+		return false
+	}
+	*p = value
+	return true
+}
+
+// GetField returns the value of the field identified by f, and false if f is not a valid Field.
+func (c *CompactParams) GetField(f Field) (int32, bool) {
+	p := c.field(f)
+	if p == nil {
+		return 0, false
+	}
+	return *p, true
+}
+
+// PnLTable identifies one of the PnL tables of CompactParams.
+//
+//pybindgen:enum
+type PnLTable int32
+
+const (
+	PnLTableRenewable PnLTable = iota
+	PnLTableBatteryArbitrage
+	PnLTableBatteryCapacity
+	PnLTableFossilWholesale
+	PnLTableFossilCapacity
+	PnLTableCapacityPool
+)
+
+// pnlTable returns a pointer to the table identified by t, or nil if t is not a valid PnLTable.
+func (c *CompactParams) pnlTable(t PnLTable) *[4]int32 {
+	switch t {
+	case PnLTableRenewable:
+		return &c.RenewablePnL
+	case PnLTableBatteryArbitrage:
+		return &c.BatteryArbitragePnL
+	case PnLTableBatteryCapacity:
+		return &c.BatteryCapacityPnL
+	case PnLTableFossilWholesale:
+		return &c.FossilWholesalePnL
+	case PnLTableFossilCapacity:
+		return &c.FossilCapacityPnL
+	case PnLTableCapacityPool:
+		return &c.CapacityPoolPnL
+	default:
+		return nil
+	}
+}
+
+// SetPnL sets one entry of a PnL table. volIdx is core.PriceVolatility (0..3).
+// Returns false if the table or volatility index is not valid.
+func (c *CompactParams) SetPnL(t PnLTable, volIdx int32, value int32) bool {
+	table := c.pnlTable(t)
+	if table == nil || volIdx < 0 || volIdx > 3 {
+		return false
+	}
+	table[volIdx] = value
+	return true
+}
+
+// SetStartingFossils sets the starting fossil count per player for games with numPlayers players. A count of 0 means
+// that games with numPlayers players are not supported. Returns false if numPlayers is out of range.
+func (c *CompactParams) SetStartingFossils(numPlayers int32, value int32) bool {
+	if numPlayers < 2 || numPlayers > MaxPlayerCount {
+		return false
+	}
+	c.StartingFossilAssetsPerPlayerCount[numPlayers] = value
+	return true
+}
@@ -18,3 +18,36 @@ func TestFromLegacyStartingFossils(t *testing.T) {
 		t.Fatalf("StartingFossils(99) = %d, want 0", got)
 	}
 }
+
+func TestSetFieldAndPnL(t *testing.T) {
+	c := Default
+	if !c.SetField(FieldCarbonTaxCost, 7) {
+		t.Fatal("SetField(FieldCarbonTaxCost) rejected a valid field")
+	}
+	if c.CarbonTaxCost != 7 {
+		t.Errorf("CarbonTaxCost = %d, want 7", c.CarbonTaxCost)
+	}
+	if got, ok := c.GetField(FieldCarbonTaxCost); !ok || got != 7 {
+		t.Errorf("GetField(FieldCarbonTaxCost) = %d, %t, want 7, true", got, ok)
+	}
+	if c.SetField(Field(-1), 7) {
+		t.Error("SetField accepted an invalid field")
+	}
+
+	if !c.SetPnL(PnLTableCapacityPool, 3, 16) {
+		t.Fatal("SetPnL(PnLTableCapacityPool, 3) rejected a valid entry")
+	}
+	if c.CapacityPoolPnL[3] != 16 {
+		t.Errorf("CapacityPoolPnL[3] = %d, want 16", c.CapacityPoolPnL[3])
+	}
+	if c.SetPnL(PnLTableCapacityPool, 4, 16) {
+		t.Error("SetPnL accepted an invalid volatility")
+	}
+
+	if !c.SetStartingFossils(8, 2) || c.StartingFossils(8) != 2 {
+		t.Errorf("SetStartingFossils(8, 2) did not set StartingFossils(8), got %d", c.StartingFossils(8))
+	}
+	if c.SetStartingFossils(1, 2) {
+		t.Error("SetStartingFossils accepted a single player game")
+	}
+}
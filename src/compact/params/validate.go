@@ -0,0 +1,180 @@
+package params
+
+import (
+	"github.com/WillMorrison/JouleQuestCardGame/params"
+)
+
+// ValidationError is a bitmask of the checks that CompactParams failed. It mirrors the checks done by
+// params.Params.Valid, grouped by the parameters involved, without allocating or formatting strings.
+//
+//pybindgen:flag
+type ValidationError uint32
+
+const (
+	ValidationErrorCapacityRule             ValidationError = 1 << iota // Capacity rule is not valid
+	ValidationErrorCarbonTaxRule                                        // Carbon tax rule is not valid
+	ValidationErrorWinConditionRule                                     // Win condition rule is not valid
+	ValidationErrorGenerationConstraintRule                             // Generation constraint rule is not valid
+	ValidationErrorTakeoverRule                                         // Takeover rule is not valid
+	ValidationErrorMarketPnL                                            // Renewable, battery arbitrage or fossil wholesale PnL don't vary with volatility correctly, or can't make losses
+	ValidationErrorCapacityPnL                                          // Capacity PnL doesn't vary with volatility correctly, or doesn't make pledging a meaningful choice
+	ValidationErrorInitialCash                                          // Initial cash is not positive
+	ValidationErrorScrapCost                                            // An asset's scrap cost is not less than its build cost
+	ValidationErrorBuildCost                                            // An asset's build cost is more than the initial cash
+	ValidationErrorRoundOneGeneration                                   // Players can't afford to replace a fossil asset with a renewable in round one
+	ValidationErrorStartingAssets                                       // No player count has starting assets, or starting assets are negative
+	ValidationErrorGenerationConstraint                                 // Starting assets don't exceed the generation constraint
+	ValidationErrorCarbonTax                                            // Carbon tax threshold or cost is not sensible
+	ValidationErrorEmissionsCap                                         // Emissions cap is too low or too high for the starting assets
+	ValidationErrorRenewablePenetration                                 // Renewable penetration is not a percentage
+)
+
+// isMonotonic checks whether each entry of the table compares to the next as required.
+func isMonotonic(table [4]int32, ok func(lower, higher int32) bool) bool {
+	for v := 0; v < 3; v++ {
+		if !ok(table[v], table[v+1]) {
+			return false
+		}
+	}
+	return true
+}
+
+func increasing(lower, higher int32) bool    { return lower < higher }
+func nonDecreasing(lower, higher int32) bool { return lower <= higher }
+func decreasing(lower, higher int32) bool    { return lower > higher }
+
+// isGreaterAndLesser checks that some entries of a are greater than the corresponding entries of b, and some are lesser.
+func isGreaterAndLesser(a, b [4]int32) bool {
+	var foundMore, foundLess bool
+	for i := range a {
+		foundLess = foundLess || a[i] < b[i]
+		foundMore = foundMore || a[i] > b[i]
+	}
+	return foundMore && foundLess
+}
+
+// Validate returns a bitmask of the validity checks that the parameters fail, or 0 if they are sensible.
+func (c CompactParams) Validate() ValidationError {
+	var errs ValidationError
+	var zeroPnL [4]int32
+
+	// Check that rule enums are valid
+	switch c.CapacityRule {
+	case params.CapacityRuleNoCapacityMarket, params.CapacityRulePaymentPerAsset, params.CapacityRuleSharedCapacityPaymentPool:
+	default:
+		errs |= ValidationErrorCapacityRule
+	}
+	switch c.CarbonTaxRule {
+	case params.CarbonTaxRuleNoCarbonTax, params.CarbonTaxRuleApplyCarbonTax:
+	default:
+		errs |= ValidationErrorCarbonTaxRule
+	}
+	switch c.WinConditionRule {
+	case params.WinConditionRuleLastFossilLoses, params.WinConditionRuleRenewablePenetrationThreshold:
+	default:
+		errs |= ValidationErrorWinConditionRule
+	}
+	switch c.GenerationConstraintRule {
+	case params.GenerationConstraintRuleMaxDecrease, params.GenerationConstraintRuleMinimum:
+	default:
+		errs |= ValidationErrorGenerationConstraintRule
+	}
+	switch c.TakeoverRule {
+	case params.TakeoverRuleForcedTakeover, params.TakeoverRuleVirtualOwner:
+	default:
+		errs |= ValidationErrorTakeoverRule
+	}
+
+	// Check that PnL does the right thing based on volatility, and that assets can have losses
+	if !isMonotonic(c.RenewablePnL, decreasing) ||
+		!isMonotonic(c.BatteryArbitragePnL, increasing) ||
+		!isMonotonic(c.FossilWholesalePnL, decreasing) ||
+		!isGreaterAndLesser(c.RenewablePnL, zeroPnL) ||
+		!isGreaterAndLesser(c.BatteryArbitragePnL, zeroPnL) ||
+		!isGreaterAndLesser(c.FossilWholesalePnL, zeroPnL) {
+		errs |= ValidationErrorMarketPnL
+	}
+
+	// Check that capacity payments vary with volatility, and that putting assets in capacity mode is a meaningful decision
+	switch c.CapacityRule {
+	case params.CapacityRulePaymentPerAsset:
+		if !isMonotonic(c.BatteryCapacityPnL, nonDecreasing) ||
+			!isMonotonic(c.FossilCapacityPnL, nonDecreasing) ||
+			!isGreaterAndLesser(c.BatteryArbitragePnL, c.BatteryCapacityPnL) ||
+			!isGreaterAndLesser(c.FossilWholesalePnL, c.FossilCapacityPnL) {
+			errs |= ValidationErrorCapacityPnL
+		}
+	case params.CapacityRuleSharedCapacityPaymentPool:
+		if !isMonotonic(c.CapacityPoolPnL, nonDecreasing) {
+			errs |= ValidationErrorCapacityPnL
+		}
+		for _, v := range c.CapacityPoolPnL {
+			if v <= 0 {
+				errs |= ValidationErrorCapacityPnL
+			}
+		}
+	}
+
+	if c.InitialCash <= 0 {
+		errs |= ValidationErrorInitialCash
+	}
+
+	// Check that build cost is more than scrap cost
+	if c.BatteryBuildCost <= c.BatteryScrapCost || c.RenewableBuildCost <= c.RenewableScrapCost || c.FossilBuildCost <= c.FossilScrapCost {
+		errs |= ValidationErrorScrapCost
+	}
+
+	// Check that build costs are less than starting money
+	if c.BatteryBuildCost > c.InitialCash || c.RenewableBuildCost > c.InitialCash || c.FossilBuildCost > c.InitialCash {
+		errs |= ValidationErrorBuildCost
+	}
+
+	// Check that generation can be kept constant in round 1
+	if c.FossilScrapCost+c.RenewableBuildCost > c.InitialCash {
+		errs |= ValidationErrorRoundOneGeneration
+	}
+
+	// Check the starting assets for each supported player count. A count of 0 means the player count is not supported.
+	var numSupported int
+	for numPlayers, numFossil := range c.StartingFossilAssetsPerPlayerCount {
+		n := int32(numPlayers)
+		switch {
+		case numFossil < 0 || (numFossil > 0 && n < 2):
+			errs |= ValidationErrorStartingAssets
+			continue
+		case numFossil == 0:
+			continue
+		}
+		numSupported++
+
+		// Computed in int64 so that large counts can't overflow into passing values
+		fossils, emissionsCap := int64(numFossil)*int64(n), int64(c.EmissionsCap)
+		if fossils <= int64(c.GenerationConstraint) {
+			errs |= ValidationErrorGenerationConstraint
+		}
+		// Counts from 1<<16 emit more than any int32 cap, so clamping them keeps the product in range
+		perPlayer := min(int64(numFossil), 1<<16)
+		if perPlayer*(perPlayer+1)/2*int64(n) >= emissionsCap || emissionsCap/fossils > 20 {
+			errs |= ValidationErrorEmissionsCap
+		}
+	}
+	if numSupported == 0 {
+		errs |= ValidationErrorStartingAssets
+	}
+
+	// Check if carbon tax parameters make sense if it's used
+	if c.CarbonTaxRule == params.CarbonTaxRuleApplyCarbonTax {
+		if c.CarbonTaxThreshold <= 0 || c.CarbonTaxCost <= 0 || c.EmissionsCap <= c.CarbonTaxThreshold {
+			errs |= ValidationErrorCarbonTax
+		}
+	}
+
+	// Check that renewable penetration goal is a percentage
+	if c.WinConditionRule == params.WinConditionRuleRenewablePenetrationThreshold {
+		if c.RenewablePenetration <= 0 || c.RenewablePenetration > 100 {
+			errs |= ValidationErrorRenewablePenetration
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,129 @@
+package params
+
+import (
+	"testing"
+
+	"github.com/WillMorrison/JouleQuestCardGame/core"
+	"github.com/WillMorrison/JouleQuestCardGame/params"
+)
+
+func TestValidateMatchesLegacy(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   params.Params
+		wantErrs ValidationError
+	}{
+		{
+			name:   "default params",
+			params: params.Default,
+		},
+		{
+			name:     "invalid capacity rule",
+			params:   params.BuilderFrom(params.Default).Capacity(params.CapacityRule(99), params.Default.BatteryCapacityPnL, params.Default.FossilCapacityPnL, core.PnLTable{}).Build(),
+			wantErrs: ValidationErrorCapacityRule,
+		},
+		{
+			name:     "invalid takeover rule",
+			params:   params.BuilderFrom(params.Default).TakeoverRule(params.TakeoverRule(99)).Build(),
+			wantErrs: ValidationErrorTakeoverRule,
+		},
+		{
+			name:     "renewable PnL increases with volatility",
+			params:   params.BuilderFrom(params.Default).PnL(params.Default.BatteryArbitragePnL, params.Default.FossilWholesalePnL, core.PnLTable{-5, 0, 5, 10}).Build(),
+			wantErrs: ValidationErrorMarketPnL,
+		},
+		{
+			name:     "shared capacity pool with zero payments",
+			params:   params.BuilderFrom(params.Default).Capacity(params.CapacityRuleSharedCapacityPaymentPool, core.PnLTable{}, core.PnLTable{}, core.PnLTable{0, 1, 2, 3}).Build(),
+			wantErrs: ValidationErrorCapacityPnL,
+		},
+		{
+			name:   "valid shared capacity pool",
+			params: params.BuilderFrom(params.Default).Capacity(params.CapacityRuleSharedCapacityPaymentPool, core.PnLTable{}, core.PnLTable{}, core.PnLTable{4, 8, 12, 16}).Build(),
+		},
+		{
+			name:   "no capacity market",
+			params: params.BuilderFrom(params.Default).Capacity(params.CapacityRuleNoCapacityMarket, core.PnLTable{}, core.PnLTable{}, core.PnLTable{}).Build(),
+		},
+		{
+			name:     "scrap costs more than building",
+			params:   params.BuilderFrom(params.Default).BatteryCosts(40, 45).Build(),
+			wantErrs: ValidationErrorScrapCost,
+		},
+		{
+			name:     "build costs more than initial cash",
+			params:   params.BuilderFrom(params.Default).RenewableCosts(60, 5).Build(),
+			wantErrs: ValidationErrorBuildCost | ValidationErrorRoundOneGeneration,
+		},
+		{
+			name:     "no starting assets",
+			params:   params.BuilderFrom(params.Default).StartingAssets(map[int]int{}).Build(),
+			wantErrs: ValidationErrorStartingAssets,
+		},
+		{
+			name:     "starting assets below generation constraint",
+			params:   params.BuilderFrom(params.Default).GenerationConstraint(params.GenerationConstraintRuleMinimum, 40).Build(),
+			wantErrs: ValidationErrorGenerationConstraint,
+		},
+		{
+			name:     "carbon tax without cost",
+			params:   params.BuilderFrom(params.Default).CarbonTax(params.CarbonTaxRuleApplyCarbonTax, 50, 0).Build(),
+			wantErrs: ValidationErrorCarbonTax,
+		},
+		{
+			name:   "valid carbon tax",
+			params: params.BuilderFrom(params.Default).CarbonTax(params.CarbonTaxRuleApplyCarbonTax, 50, 1).Build(),
+		},
+		{
+			name:     "emissions cap too low",
+			params:   params.BuilderFrom(params.Default).EmissionsCap(50).Build(),
+			wantErrs: ValidationErrorEmissionsCap,
+		},
+		{
+			name:     "renewable penetration over 100%",
+			params:   params.BuilderFrom(params.Default).WinConditionRule(params.WinConditionRuleRenewablePenetrationThreshold, 101).Build(),
+			wantErrs: ValidationErrorRenewablePenetration,
+		},
+		{
+			name:   "valid renewable penetration",
+			params: params.BuilderFrom(params.Default).WinConditionRule(params.WinConditionRuleRenewablePenetrationThreshold, 80).Build(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := FromLegacy(tt.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := c.Validate()
+			if got != tt.wantErrs {
+				t.Errorf("Validate() = %b, want %b", got, tt.wantErrs)
+			}
+			legacyErr := tt.params.Valid()
+			if (got == 0) != (legacyErr == nil) {
+				t.Errorf("Validate() = %b disagrees with legacy Valid() = %v", got, legacyErr)
+			}
+		})
+	}
+}
+
+func TestValidateLargeStartingFossils(t *testing.T) {
+	// 65536 fossils each emit 65536*65537/2*2 = 4295032832 over the game, which wraps to 65536 in int32
+	c := Default
+	c.SetField(FieldEmissionsCap, 100000)
+	for n := int32(3); n <= MaxPlayerCount; n++ {
+		c.SetStartingFossils(n, 0)
+	}
+	c.SetStartingFossils(2, 65536)
+
+	if got := c.Validate(); got&ValidationErrorEmissionsCap == 0 {
+		t.Errorf("Validate() = %b, want %b set", got, ValidationErrorEmissionsCap)
+	}
+}
+
+func TestValidateZeroValue(t *testing.T) {
+	var c CompactParams
+	if c.Validate() == 0 {
+		t.Error("Validate() accepted zero value params")
+	}
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"unsafe"
+
+	"github.com/WillMorrison/JouleQuestCardGame/compact/game"
+	"github.com/WillMorrison/JouleQuestCardGame/compact/params"
+	"github.com/WillMorrison/JouleQuestCardGame/core"
+)
+
+// Batched mode runs up to maxBatchSize independent games in linear memory, so that a host can step many environments
+// with a single call. The host exchanges data through fixed int32 buffers whose addresses are exported:
+//
+//   - actions[env]: input, the action code to apply for the acting player of each environment.
+//   - players[env]: output, the index of the player who acts next in each environment.
+//   - observations[env*ObservationSize() + i]: output, the acting player's observation from Game.EncodeObservation,
+//     under the policy set by SetVisibility.
+//   - masks[env]: output, the acting player's possible action mask.
+//   - rewards[env*MaxPlayers() + player]: output, each player's reward for the last step, under the scheme set by
+//     SetBatchRewardScheme (terminal by default).
+//   - done[env]: output, 1 if the game finished in the last step, in which case it has already been reset and the
+//     other outputs describe the first position of the next game.
+//   - codes[env]: output, the ErrCode from applying the action. The game is unchanged if this is not OK.
+//
+// The batch is independent from the single game used by the other exports, but uses the same parameters.
+
+const maxBatchSize = 1024
+
+var (
+	gBatchSize         int32
+	gBatchNumPlayers   int32
+	gBatchRewardScheme core.RewardScheme
+	gBatchGames        [maxBatchSize]game.Game
+	gBatchActions      [maxBatchSize]int32
+	gBatchPlayers      [maxBatchSize]int32
+	gBatchObs          [maxBatchSize * game.ObsSize]int32
+	gBatchMasks        [maxBatchSize]int32
+	gBatchRewards      [maxBatchSize * params.MaxPlayers]int32
+	gBatchDone         [maxBatchSize]int32
+	gBatchCodes        [maxBatchSize]int32
+)
+
+// address returns the linear memory address of a buffer, for the host to read from or write to.
+func address(p *int32) int32 {
+	return int32(uintptr(unsafe.Pointer(p)))
+}
+
+//go:wasmexport MaxBatchSize
+func MaxBatchSize() int32 {
+	return maxBatchSize
+}
+
+//go:wasmexport MaxPlayers
+func MaxPlayers() int32 {
+	return params.MaxPlayers
+}
+
+//go:wasmexport BatchActionsPtr
+func BatchActionsPtr() int32 {
+	return address(&gBatchActions[0])
+}
+
+//go:wasmexport BatchPlayersPtr
+func BatchPlayersPtr() int32 {
+	return address(&gBatchPlayers[0])
+}
+
+//go:wasmexport BatchObservationsPtr
+func BatchObservationsPtr() int32 {
+	return address(&gBatchObs[0])
+}
+
+//go:wasmexport BatchMasksPtr
+func BatchMasksPtr() int32 {
+	return address(&gBatchMasks[0])
+}
+
+//go:wasmexport BatchRewardsPtr
+func BatchRewardsPtr() int32 {
+	return address(&gBatchRewards[0])
+}
+
+//go:wasmexport BatchDonePtr
+func BatchDonePtr() int32 {
+	return address(&gBatchDone[0])
+}
+
+//go:wasmexport BatchCodesPtr
+func BatchCodesPtr() int32 {
+	return address(&gBatchCodes[0])
+}
+
+//go:wasmexport SetBatchRewardScheme
+func SetBatchRewardScheme(scheme int32) int32 {
+	if scheme < int32(core.RewardSchemeTerminal) || scheme > int32(core.RewardSchemeShaped) {
+		return int32(game.CodeInvalidParam)
+	}
+	gBatchRewardScheme = core.RewardScheme(scheme)
+	return int32(game.CodeOK)
+}
+
+// BatchReset starts batchSize new games with numPlayers each, and writes their initial outputs. Environment i is seeded
+// with seed+i. The arguments are validated before any environment is reset, so on error the batch is unchanged.
+//
+//go:wasmexport BatchReset
+func BatchReset(batchSize int32, numPlayers int32, seed int32) int32 {
+	if batchSize < 1 || batchSize > maxBatchSize {
+		return int32(game.CodeInvalidParam)
+	}
+	if code := game.CheckPlayerCount(numPlayers, gParams); code != game.CodeOK {
+		return int32(code)
+	}
+	for env := int32(0); env < batchSize; env++ {
+		g := &gBatchGames[env]
+		g.Reset(numPlayers, gParams)
+		g.SetRNGSeed(uint64(uint32(seed)) + uint64(env))
+		gBatchPlayers[env] = nextPlayer(g, -1)
+		gBatchDone[env] = 0
+		gBatchCodes[env] = int32(game.CodeOK)
+		clear(gBatchRewards[env*params.MaxPlayers : (env+1)*params.MaxPlayers])
+		writeBatchOutputs(env)
+	}
+	gBatchSize = batchSize
+	gBatchNumPlayers = numPlayers
+	return int32(game.CodeOK)
+}
+
+// StepBatch applies actions[env] for the acting player of every environment, auto-resets finished games, and writes
+// all outputs. Returns the number of environments whose action was invalid.
+//
+//go:wasmexport StepBatch
+func StepBatch() int32 {
+	var invalid int32
+	for env := int32(0); env < gBatchSize; env++ {
+		g := &gBatchGames[env]
+		pi := gBatchPlayers[env]
+		rewards := gBatchRewards[env*params.MaxPlayers : (env+1)*params.MaxPlayers]
+		clear(rewards)
+		gBatchDone[env] = 0
+
+		code := g.ApplyPlayerAction(pi, gBatchActions[env])
+		gBatchCodes[env] = int32(code)
+		if code != game.CodeOK {
+			invalid++
+			writeBatchOutputs(env)
+			continue
+		}
+		for i := int32(0); i < g.NumPlayers; i++ {
+			rewards[i] = g.Reward(i, gBatchRewardScheme)
+		}
+
+		if g.Status != core.GameStatusOngoing {
+			gBatchDone[env] = 1
+			g.Reset(gBatchNumPlayers, gParams)
+			pi = -1
+		}
+		gBatchPlayers[env] = nextPlayer(g, pi)
+		writeBatchOutputs(env)
+	}
+	return invalid
+}
+
+// nextPlayer returns the first player after pi (wrapping around) who has possible actions, so that players take turns.
+func nextPlayer(g *game.Game, pi int32) int32 {
+	for i := int32(1); i <= g.NumPlayers; i++ {
+		next := (pi + i) % g.NumPlayers
+		if next < 0 {
+			next += g.NumPlayers
+		}
+		if g.PossibleActionMask(next) != 0 {
+			return next
+		}
+	}
+	return 0
+}
+
+func writeBatchOutputs(env int32) {
+	g := &gBatchGames[env]
+	pi := gBatchPlayers[env]
+	gBatchMasks[env] = int32(g.PossibleActionMask(pi))
+
+	g.EncodeObservation(pi, gVisibility, gBatchObs[env*int32(game.ObsSize):(env+1)*int32(game.ObsSize)])
+}
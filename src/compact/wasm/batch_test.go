@@ -0,0 +1,127 @@
+package main
+
+import (
+	"math/bits"
+	"testing"
+
+	cgame "github.com/WillMorrison/JouleQuestCardGame/compact/game"
+	"github.com/WillMorrison/JouleQuestCardGame/compact/params"
+	"github.com/WillMorrison/JouleQuestCardGame/core"
+)
+
+func TestBatchResetErrors(t *testing.T) {
+	if code := BatchReset(0, 2, 0); code != int32(cgame.CodeInvalidParam) {
+		t.Errorf("BatchReset(0): %d", code)
+	}
+	if code := BatchReset(MaxBatchSize()+1, 2, 0); code != int32(cgame.CodeInvalidParam) {
+		t.Errorf("BatchReset(MaxBatchSize()+1): %d", code)
+	}
+	if code := BatchReset(2, 1, 0); code != int32(cgame.CodeInvalidPlayerCount) {
+		t.Errorf("BatchReset with 1 player: %d", code)
+	}
+}
+
+func TestBatchResetErrorLeavesBatchUnchanged(t *testing.T) {
+	BatchReset(2, 2, 0)
+	gBatchActions[0] = cgame.ActionBuildRenewable
+	StepBatch()
+	before := gBatchGames[0]
+
+	if code := BatchReset(2, params.MaxPlayers+1, 0); code != int32(cgame.CodeInvalidPlayerCount) {
+		t.Fatalf("BatchReset with too many players: %d", code)
+	}
+
+	if gBatchGames[0] != before || gBatchSize != 2 || gBatchNumPlayers != 2 {
+		t.Errorf("failed BatchReset changed the batch")
+	}
+}
+
+func TestStepBatchInvalidAction(t *testing.T) {
+	BatchReset(2, 2, 0)
+	before := gBatchGames[0]
+	gBatchActions[0] = 99
+	gBatchActions[1] = cgame.ActionFinished
+
+	if invalid := StepBatch(); invalid != 1 {
+		t.Fatalf("StepBatch() = %d, want 1 invalid action", invalid)
+	}
+
+	if gBatchCodes[0] != int32(cgame.CodeInvalidAction) || gBatchCodes[1] != int32(cgame.CodeOK) {
+		t.Errorf("codes = %v, want [%d %d]", gBatchCodes[:2], cgame.CodeInvalidAction, cgame.CodeOK)
+	}
+	if gBatchGames[0] != before {
+		t.Errorf("game with invalid action changed")
+	}
+	if gBatchPlayers[1] != 1 {
+		t.Errorf("next player in env 1 = %d, want 1", gBatchPlayers[1])
+	}
+}
+
+func TestStepBatchPlaysAndAutoResets(t *testing.T) {
+	const batchSize = 8
+	if code := BatchReset(batchSize, 3, 42); code != int32(cgame.CodeOK) {
+		t.Fatalf("BatchReset: %d", code)
+	}
+
+	var finished int
+	for step := 0; step < 10000 && finished < batchSize; step++ {
+		// Pick the lowest possible action that isn't finishing, so that games are not all identical.
+		for env := range batchSize {
+			mask := uint32(gBatchMasks[env])
+			if mask == 0 {
+				t.Fatalf("step %d env %d: no possible actions for player %d", step, env, gBatchPlayers[env])
+			}
+			action := bits.TrailingZeros32(mask)
+			if step%3 == 0 || action == cgame.ActionFinished {
+				action = bits.Len32(mask) - 1
+			}
+			gBatchActions[env] = int32(action)
+		}
+
+		if invalid := StepBatch(); invalid != 0 {
+			t.Fatalf("step %d: %d invalid actions", step, invalid)
+		}
+
+		for env := range batchSize {
+			if gBatchDone[env] == 0 {
+				continue
+			}
+			finished++
+			g := &gBatchGames[env]
+			if g.Round != 1 {
+				t.Errorf("step %d env %d: finished game was not reset, round %d", step, env, g.Round)
+			}
+			var anyReward bool
+			for _, r := range gBatchRewards[env*params.MaxPlayers : (env+1)*params.MaxPlayers] {
+				anyReward = anyReward || r != 0
+			}
+			if !anyReward {
+				t.Errorf("step %d env %d: finished game has no terminal rewards", step, env)
+			}
+			if obs := gBatchObs[env*int(cgame.ObsSize):]; obs[cgame.ObsRound] != 1 {
+				t.Errorf("step %d env %d: observation round %d, want 1", step, env, obs[cgame.ObsRound])
+			}
+		}
+	}
+	if finished < batchSize {
+		t.Errorf("only %d games finished", finished)
+	}
+}
+
+func TestSetBatchRewardScheme(t *testing.T) {
+	defer SetBatchRewardScheme(int32(core.RewardSchemeTerminal))
+	if code := SetBatchRewardScheme(int32(core.RewardSchemeShaped) + 1); code != int32(cgame.CodeInvalidParam) {
+		t.Errorf("SetBatchRewardScheme(invalid): %d", code)
+	}
+	if code := SetBatchRewardScheme(int32(core.RewardSchemeMoneyDelta)); code != int32(cgame.CodeOK) {
+		t.Fatalf("SetBatchRewardScheme: %d", code)
+	}
+	BatchReset(1, 2, 0)
+	gBatchActions[0] = cgame.ActionBuildRenewable
+
+	StepBatch()
+
+	if want := -params.Default.RenewableBuildCost; gBatchRewards[0] != want {
+		t.Errorf("reward = %d, want %d", gBatchRewards[0], want)
+	}
+}
@@ -29,7 +29,7 @@ func CarbonEmissions() int32 {
 
 //go:wasmexport PlayerMoney
 func PlayerMoney(playerIndex int32) int32 {
-	return gGame.PlayerMoney(playerIndex)
+	return gGame.ObservedPlayerMoney(gObserver, playerIndex, gVisibility)
 }
 
 //go:wasmexport PlayerStatus
@@ -44,27 +44,27 @@ func PlayerLossReason(playerIndex int32) int32 {
 
 //go:wasmexport PlayerRenewableAssets
 func PlayerRenewableAssets(playerIndex int32) int32 {
-	return int32(gGame.PlayerAssetMix(playerIndex).Renewables)
+	return int32(gGame.ObservedPlayerAssetMix(gObserver, playerIndex, gVisibility).Renewables)
 }
 
 //go:wasmexport PlayerBatteriesArbitrageAssets
 func PlayerBatteriesArbitrageAssets(playerIndex int32) int32 {
-	return int32(gGame.PlayerAssetMix(playerIndex).BatteriesArbitrage)
+	return int32(gGame.ObservedPlayerAssetMix(gObserver, playerIndex, gVisibility).BatteriesArbitrage)
 }
 
 //go:wasmexport PlayerBatteriesCapacityAssets
 func PlayerBatteriesCapacityAssets(playerIndex int32) int32 {
-	return int32(gGame.PlayerAssetMix(playerIndex).BatteriesCapacity)
+	return int32(gGame.ObservedPlayerAssetMix(gObserver, playerIndex, gVisibility).BatteriesCapacity)
 }
 
 //go:wasmexport PlayerFossilsWholesaleAssets
 func PlayerFossilsWholesaleAssets(playerIndex int32) int32 {
-	return int32(gGame.PlayerAssetMix(playerIndex).FossilsWholesale)
+	return int32(gGame.ObservedPlayerAssetMix(gObserver, playerIndex, gVisibility).FossilsWholesale)
 }
 
 //go:wasmexport PlayerFossilsCapacityAssets
 func PlayerFossilsCapacityAssets(playerIndex int32) int32 {
-	return int32(gGame.PlayerAssetMix(playerIndex).FossilsCapacity)
+	return int32(gGame.ObservedPlayerAssetMix(gObserver, playerIndex, gVisibility).FossilsCapacity)
 }
 
 //go:wasmexport TakeoverRenewableAssets
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"github.com/WillMorrison/JouleQuestCardGame/compact/game"
+	"github.com/WillMorrison/JouleQuestCardGame/core"
+)
+
+var (
+	gObservation [game.ObsSize]int32
+	gVisibility  core.Visibility
+	gObserver    int32 = game.NoObserver
+)
+
+//go:wasmexport ObservationVersion
+func ObservationVersion() int32 {
+	return game.ObservationVersion
+}
+
+//go:wasmexport ObservationSize
+func ObservationSize() int32 {
+	return int32(game.ObsSize)
+}
+
+//go:wasmexport ObservationPtr
+func ObservationPtr() int32 {
+	return address(&gObservation[0])
+}
+
+// SetVisibility selects the core.Visibility policy applied to observations, batch observations and the player getters.
+//
+//go:wasmexport SetVisibility
+func SetVisibility(policy int32) int32 {
+	if policy < int32(core.VisibilityFull) || policy > int32(core.VisibilityBucketedMix) {
+		return int32(game.CodeInvalidParam)
+	}
+	gVisibility = core.Visibility(policy)
+	return int32(game.CodeOK)
+}
+
+// SetObserver selects the player whose view the player getters report under the visibility policy, or -1 for a
+// spectator, who sees every player as an opponent.
+//
+//go:wasmexport SetObserver
+func SetObserver(playerIndex int32) int32 {
+	if playerIndex < game.NoObserver || playerIndex >= gGame.NumPlayers {
+		return int32(game.CodeInvalidParam)
+	}
+	gObserver = playerIndex
+	return int32(game.CodeOK)
+}
+
+// EncodeObservation writes the given player's observation under the visibility policy into the buffer at
+// ObservationPtr, replacing the calls to the individual getters.
+//
+//go:wasmexport EncodeObservation
+func EncodeObservation(playerIndex int32) int32 {
+	return int32(gGame.EncodeObservation(playerIndex, gVisibility, gObservation[:]))
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"github.com/WillMorrison/JouleQuestCardGame/compact/game"
+	cparams "github.com/WillMorrison/JouleQuestCardGame/compact/params"
+	"github.com/WillMorrison/JouleQuestCardGame/params"
+)
+
+// Parameter setters change gParams, which takes effect on the next Reset. They do not check that the parameters are
+// sensible, call ValidateParams for that.
+
+func boolToCode(ok bool) int32 {
+	if ok {
+		return int32(game.CodeOK)
+	}
+	return int32(game.CodeInvalidParam)
+}
+
+//go:wasmexport ResetParams
+func ResetParams() {
+	gParams = cparams.Default
+}
+
+//go:wasmexport ValidateParams
+func ValidateParams() int32 {
+	return int32(gParams.Validate())
+}
+
+//go:wasmexport SetParam
+func SetParam(fieldId int32, value int32) int32 {
+	return boolToCode(gParams.SetField(cparams.Field(fieldId), value))
+}
+
+//go:wasmexport Param
+func Param(fieldId int32) int32 {
+	v, _ := gParams.GetField(cparams.Field(fieldId))
+	return v
+}
+
+//go:wasmexport SetPnL
+func SetPnL(table int32, volatility int32, value int32) int32 {
+	return boolToCode(gParams.SetPnL(cparams.PnLTable(table), volatility, value))
+}
+
+//go:wasmexport SetStartingFossils
+func SetStartingFossils(numPlayers int32, value int32) int32 {
+	return boolToCode(gParams.SetStartingFossils(numPlayers, value))
+}
+
+//go:wasmexport SetCapacityRule
+func SetCapacityRule(rule int32) {
+	gParams.CapacityRule = params.CapacityRule(rule)
+}
+
+//go:wasmexport SetCarbonTaxRule
+func SetCarbonTaxRule(rule int32) {
+	gParams.CarbonTaxRule = params.CarbonTaxRule(rule)
+}
+
+//go:wasmexport SetWinConditionRule
+func SetWinConditionRule(rule int32) {
+	gParams.WinConditionRule = params.WinConditionRule(rule)
+}
+
+//go:wasmexport SetGenerationConstraintRule
+func SetGenerationConstraintRule(rule int32) {
+	gParams.GenerationConstraintRule = params.GenerationConstraintRule(rule)
+}
+
+//go:wasmexport SetTakeoverRule
+func SetTakeoverRule(rule int32) {
+	gParams.TakeoverRule = params.TakeoverRule(rule)
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"testing"
+
+	cgame "github.com/WillMorrison/JouleQuestCardGame/compact/game"
+	cparams "github.com/WillMorrison/JouleQuestCardGame/compact/params"
+	"github.com/WillMorrison/JouleQuestCardGame/params"
+)
+
+func TestSetParamTakesEffectOnReset(t *testing.T) {
+	defer ResetParams()
+
+	if code := SetParam(int32(cparams.FieldInitialCash), 80); code != int32(cgame.CodeOK) {
+		t.Fatalf("SetParam: %d", code)
+	}
+	if code := SetStartingFossils(2, 8); code != int32(cgame.CodeOK) {
+		t.Fatalf("SetStartingFossils: %d", code)
+	}
+	if errs := ValidateParams(); errs != 0 {
+		t.Fatalf("ValidateParams: %b", errs)
+	}
+	if code := Reset(2); code != int32(cgame.CodeOK) {
+		t.Fatalf("Reset: %d", code)
+	}
+	if PlayerMoney(0) != 80 {
+		t.Errorf("PlayerMoney(0) = %d, want 80", PlayerMoney(0))
+	}
+	if PlayerFossilsWholesaleAssets(0) != 8 {
+		t.Errorf("PlayerFossilsWholesaleAssets(0) = %d, want 8", PlayerFossilsWholesaleAssets(0))
+	}
+	if Param(int32(cparams.FieldInitialCash)) != 80 {
+		t.Errorf("Param(FieldInitialCash) = %d, want 80", Param(int32(cparams.FieldInitialCash)))
+	}
+}
+
+func TestSetParamErrors(t *testing.T) {
+	defer ResetParams()
+
+	if code := SetParam(-1, 0); code != int32(cgame.CodeInvalidParam) {
+		t.Errorf("SetParam bad field: %d", code)
+	}
+	if code := SetPnL(int32(cparams.PnLTableRenewable), 4, 0); code != int32(cgame.CodeInvalidParam) {
+		t.Errorf("SetPnL bad volatility: %d", code)
+	}
+	if code := SetStartingFossils(cparams.MaxPlayerCount+1, 3); code != int32(cgame.CodeInvalidParam) {
+		t.Errorf("SetStartingFossils bad player count: %d", code)
+	}
+}
+
+func TestValidateParamsReportsErrors(t *testing.T) {
+	defer ResetParams()
+
+	SetCapacityRule(int32(params.CapacityRuleSharedCapacityPaymentPool))
+	if errs := ValidateParams(); errs != int32(cparams.ValidationErrorCapacityPnL) {
+		t.Errorf("ValidateParams with empty capacity pool = %b, want %b", errs, cparams.ValidationErrorCapacityPnL)
+	}
+	for vol, pnl := range []int32{4, 8, 12, 16} {
+		SetPnL(int32(cparams.PnLTableCapacityPool), int32(vol), pnl)
+	}
+	if errs := ValidateParams(); errs != 0 {
+		t.Errorf("ValidateParams with capacity pool = %b, want 0", errs)
+	}
+
+	ResetParams()
+	SetTakeoverRule(99)
+	if errs := ValidateParams(); errs != int32(cparams.ValidationErrorTakeoverRule) {
+		t.Errorf("ValidateParams with bad takeover rule = %b, want %b", errs, cparams.ValidationErrorTakeoverRule)
+	}
+}
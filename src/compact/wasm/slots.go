@@ -0,0 +1,52 @@
+package main
+
+import "github.com/WillMorrison/JouleQuestCardGame/compact/game"
+
+// maxSaveSlots is the number of game snapshots that can be held at once. Slots are preallocated so that saving and
+// restoring never allocates.
+const maxSaveSlots = 64
+
+var (
+	gSlots     [maxSaveSlots]game.Game
+	gSlotSaved [maxSaveSlots]bool
+)
+
+//go:wasmexport MaxSaveSlots
+func MaxSaveSlots() int32 {
+	return maxSaveSlots
+}
+
+// SaveSlot snapshots the current game, including its RNG state, into the given slot, overwriting any previous snapshot.
+//
+//go:wasmexport SaveSlot
+func SaveSlot(slot int32) int32 {
+	if slot < 0 || slot >= maxSaveSlots {
+		return int32(game.CodeInvalidSlot)
+	}
+	gSlots[slot].CopyFrom(&gGame)
+	gSlotSaved[slot] = true
+	return int32(game.CodeOK)
+}
+
+// RestoreSlot replaces the current game with the snapshot in the given slot. The slot keeps its snapshot, so it can be
+// restored repeatedly.
+//
+//go:wasmexport RestoreSlot
+func RestoreSlot(slot int32) int32 {
+	if slot < 0 || slot >= maxSaveSlots || !gSlotSaved[slot] {
+		return int32(game.CodeInvalidSlot)
+	}
+	gGame.CopyFrom(&gSlots[slot])
+	return int32(game.CodeOK)
+}
+
+// ClearSlot discards the snapshot in the given slot.
+//
+//go:wasmexport ClearSlot
+func ClearSlot(slot int32) int32 {
+	if slot < 0 || slot >= maxSaveSlots {
+		return int32(game.CodeInvalidSlot)
+	}
+	gSlotSaved[slot] = false
+	return int32(game.CodeOK)
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"github.com/WillMorrison/JouleQuestCardGame/compact/game"
 	"github.com/WillMorrison/JouleQuestCardGame/compact/params"
+	"github.com/WillMorrison/JouleQuestCardGame/core"
 )
 
 var (
@@ -26,6 +27,24 @@ func ApplyAction(playerIndex int32, actionInt int32) int32 {
 	return int32(gGame.ApplyPlayerAction(playerIndex, actionInt))
 }
 
+//go:wasmexport Reward
+func Reward(playerIndex int32, scheme int32) int32 {
+	return gGame.Reward(playerIndex, core.RewardScheme(scheme))
+}
+
+// Undo takes back the most recent action of the current build phase. Up to MaxUndo actions can be undone in a row,
+// and it returns CodeNothingToUndo once there are none left, or outside the build phase.
+//
+//go:wasmexport Undo
+func Undo() int32 {
+	return int32(gGame.Undo())
+}
+
+//go:wasmexport MaxUndo
+func MaxUndo() int32 {
+	return game.MaxUndo
+}
+
 //go:wasmexport SetRNGSeed
 func SetRNGSeed(seed int32) {
 	gGame.SetRNGSeed(uint64(uint32(seed)))
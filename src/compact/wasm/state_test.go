@@ -55,3 +55,80 @@ func TestAssetBucketGetters(t *testing.T) {
 		t.Fatal("expected empty takeover pool")
 	}
 }
+
+func TestUndo(t *testing.T) {
+	Reset(2)
+	money := PlayerMoney(0)
+	ApplyAction(0, cgame.ActionBuildRenewable)
+
+	if code := Undo(); code != int32(cgame.CodeOK) {
+		t.Fatalf("Undo: %d", code)
+	}
+	if PlayerMoney(0) != money || PlayerRenewableAssets(0) != 0 {
+		t.Fatalf("Undo did not restore player 0: money %d, renewables %d", PlayerMoney(0), PlayerRenewableAssets(0))
+	}
+	if code := Undo(); code != int32(cgame.CodeNothingToUndo) {
+		t.Fatalf("Undo with empty history: %d", code)
+	}
+}
+
+func TestEncodeObservation(t *testing.T) {
+	Reset(2)
+
+	if code := EncodeObservation(1); code != int32(cgame.CodeOK) {
+		t.Fatalf("EncodeObservation: %d", code)
+	}
+
+	if gObservation[cgame.ObsVersion] != ObservationVersion() {
+		t.Errorf("version %d, want %d", gObservation[cgame.ObsVersion], ObservationVersion())
+	}
+	if gObservation[cgame.ObsOwnMoney] != PlayerMoney(1) {
+		t.Errorf("own money %d, want %d", gObservation[cgame.ObsOwnMoney], PlayerMoney(1))
+	}
+	if code := EncodeObservation(2); code != int32(cgame.CodeInvalidParam) {
+		t.Errorf("EncodeObservation for missing player: %d", code)
+	}
+}
+
+func TestReward(t *testing.T) {
+	Reset(2)
+	money := PlayerMoney(0)
+	ApplyAction(0, cgame.ActionBuildRenewable)
+
+	if r := Reward(0, int32(core.RewardSchemeMoneyDelta)); r != PlayerMoney(0)-money {
+		t.Errorf("money delta reward = %d, want %d", r, PlayerMoney(0)-money)
+	}
+	if r := Reward(0, int32(core.RewardSchemeTerminal)); r != 0 {
+		t.Errorf("terminal reward = %d, want 0", r)
+	}
+}
+
+func TestSetVisibility(t *testing.T) {
+	defer SetVisibility(int32(core.VisibilityFull))
+	defer SetObserver(cgame.NoObserver)
+	Reset(2)
+
+	if code := SetVisibility(int32(core.VisibilityBucketedMix) + 1); code != int32(cgame.CodeInvalidParam) {
+		t.Errorf("SetVisibility(invalid): %d", code)
+	}
+	if code := SetObserver(2); code != int32(cgame.CodeInvalidParam) {
+		t.Errorf("SetObserver(missing player): %d", code)
+	}
+	if code := SetVisibility(int32(core.VisibilityHiddenMoney)); code != int32(cgame.CodeOK) {
+		t.Fatalf("SetVisibility: %d", code)
+	}
+	if code := SetObserver(0); code != int32(cgame.CodeOK) {
+		t.Fatalf("SetObserver: %d", code)
+	}
+
+	if PlayerMoney(0) != gGame.Players[0].Money {
+		t.Errorf("observer's own money %d, want %d", PlayerMoney(0), gGame.Players[0].Money)
+	}
+	if PlayerMoney(1) != 0 {
+		t.Errorf("opponent money %d, want hidden", PlayerMoney(1))
+	}
+	EncodeObservation(0)
+	if money := gObservation[cgame.ObsOpponents+cgame.ObsField(cgame.ObsOpponentMoney)]; money != 0 {
+		t.Errorf("observed opponent money %d, want hidden", money)
+	}
+}
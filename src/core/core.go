@@ -96,3 +96,76 @@ const (
 func (lc LossCondition) LogKey() string {
 	return "loss_reason"
 }
+
+// MaxUndo is the number of most recent build-phase actions that can be undone in a row. Both engines keep the same
+// number of actions so that they agree on which undos succeed.
+const MaxUndo = 8
+
+// Ways of computing a per-player reward for a game transition, for reinforcement learning
+//
+//pybindgen:enum
+type RewardScheme int
+
+//go:generate go tool stringer -type=RewardScheme -trimprefix=RewardScheme
+const (
+	// +1 for each surviving player when the game is won, -1 for a player who loses individually or when the game is lost. Default.
+	RewardSchemeTerminal RewardScheme = iota
+
+	// The change in the player's money.
+	RewardSchemeMoneyDelta
+
+	// The terminal reward multiplied by ShapedTerminalWeight, plus the change in renewable penetration of the last
+	// snapshot, minus the carbon emissions added. Only players who were active before the transition are rewarded.
+	RewardSchemeShaped
+)
+
+// ShapedTerminalWeight scales the terminal reward in RewardSchemeShaped, so that winning or losing outweighs shaping.
+const ShapedTerminalWeight = 100
+
+func (rs RewardScheme) LogKey() string {
+	return "reward_scheme"
+}
+
+// Rules for which parts of the other players' state an observer can see, for hidden-information play
+//
+//pybindgen:enum
+type Visibility int
+
+//go:generate go tool stringer -type=Visibility -trimprefix=Visibility
+const (
+	// Every player's state is visible. Default.
+	VisibilityFull Visibility = iota
+
+	// Opponents' money is hidden.
+	VisibilityHiddenMoney
+
+	// Opponents' asset counts are only visible as the lower bound of their bucket in MixBuckets.
+	VisibilityBucketedMix
+)
+
+// MixBuckets are the lower bounds of the buckets that opponents' asset counts of each type are reported in under
+// VisibilityBucketedMix, like judging the size of a pile of cards at a glance. Having none of a type stays visible.
+var MixBuckets = [...]int{0, 1, 3, 6}
+
+func (v Visibility) LogKey() string {
+	return "visibility"
+}
+
+// HidesOpponentMoney returns whether observers cannot see their opponents' money.
+func (v Visibility) HidesOpponentMoney() bool {
+	return v == VisibilityHiddenMoney
+}
+
+// OpponentAssetCount returns how many assets of one type an observer sees an opponent with n assets of that type own.
+func (v Visibility) OpponentAssetCount(n int) int {
+	if v != VisibilityBucketedMix {
+		return n
+	}
+	bucket := 0
+	for _, lower := range MixBuckets {
+		if n >= lower {
+			bucket = lower
+		}
+	}
+	return bucket
+}
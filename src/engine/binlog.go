@@ -0,0 +1,544 @@
+// This file implements a compact binary format for game logs
+
+package engine
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/WillMorrison/JouleQuestCardGame/eventlog"
+)
+
+/*
+A binary game log starts with binaryLogMagic and a version byte, followed by records. Each record is the uvarint length
+of its body, then the body:
+
+	event        byte     GameLogEvent, or rawRecord
+	state        byte     StateMachineState
+	round        uvarint  0 if the event has no round
+	player index uvarint  Index plus 1, or 0 if the event's payload has no player_index
+	payload      ...      Each payload field but player_index, in order
+
+Payload fields have no keys or types, as the payload type follows from the event and state. Integers are zigzag
+varints, unsigned integers are uvarints, strings are a uvarint length and bytes, and structs are their exported fields
+in order. Slices and maps are a uvarint count plus 1, or 0 if nil, then their elements, maps sorted by key. Pointers
+are a presence byte, then the value if it is present.
+
+Enums, including the event and state bytes, are stored as their numbers rather than their names, so the numbers of
+existing enum values are frozen: new values must be appended, and removing or reordering values needs a new
+binaryLogVersion. Test_BinaryLog_EnumValuesFrozen pins the current values.
+
+Events which aren't game log events with exactly the keys of their payload type are stored as rawRecord: the event
+byte, then the event as JSON.
+*/
+
+const binaryLogMagic = "JQGL"
+
+// binaryLogVersion is incremented whenever the record layout changes, including the fields of any payload type.
+const binaryLogVersion = 1
+
+const rawRecord = 0xff
+
+var ErrInvalidBinaryLog = errors.New("invalid binary game log")
+
+// binaryLogWriter encodes events to a binary game log.
+type binaryLogWriter struct {
+	mu    sync.Mutex // Guards the buffers and writes, which Sub loggers share
+	w     io.Writer
+	body  []byte
+	frame []byte
+}
+
+// NewBinaryLogger writes the header of a binary game log to w, and returns a Logger writing events to it as records.
+// Like the JSON logger, it ignores errors writing events. Games sharing the log should each use a Sub logger, and may
+// log concurrently.
+func NewBinaryLogger(w io.Writer) (eventlog.Logger, error) {
+	if _, err := w.Write(append([]byte(binaryLogMagic), binaryLogVersion)); err != nil {
+		return nil, err
+	}
+	bw := &binaryLogWriter{w: w}
+	return eventlog.NewFieldLogger(bw.log), nil
+}
+
+func (bw *binaryLogWriter) log(fields []eventlog.Field) {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+	if rec, ok := recordFromFields(fields); ok {
+		bw.body = appendRecord(bw.body[:0], rec)
+	} else {
+		bw.body = appendRawRecord(bw.body[:0], fields)
+	}
+	bw.frame = binary.AppendUvarint(bw.frame[:0], uint64(len(bw.body)))
+	bw.frame = append(bw.frame, bw.body...)
+	bw.w.Write(bw.frame)
+}
+
+// fieldEnum returns the value of an enum field, logged by With or by name.
+func fieldEnum[T eventlog.Enum](f eventlog.Field) (T, bool) {
+	if v, ok := f.Loggable.(T); ok {
+		return v, true
+	}
+	name, ok := f.Value.(string)
+	if !ok {
+		return 0, false
+	}
+	v, err := eventlog.ParseEnum[T](name)
+	return v, err == nil
+}
+
+// recordFromFields returns the record of an event logged by the engine, or false if the fields are not exactly those
+// of a game log event.
+func recordFromFields(fields []eventlog.Field) (GameLogRecord, bool) {
+	byKey := make(map[string]eventlog.Field, len(fields))
+	for _, f := range fields {
+		byKey[f.Key] = f
+	}
+	var rec GameLogRecord
+	gle, ok := fieldEnum[GameLogEvent](byKey[GameLogEventStateMachineTransition.LogKey()])
+	if !ok {
+		return GameLogRecord{}, false
+	}
+	if rec.State, ok = fieldEnum[StateMachineState](byKey[StateMachineStateGameStart.LogKey()]); !ok {
+		return GameLogRecord{}, false
+	}
+	used := 2
+	if f, ok := byKey["round"]; ok {
+		if rec.Round, ok = f.Value.(int); !ok || rec.Round <= 0 {
+			return GameLogRecord{}, false
+		}
+		used++
+	}
+
+	t, ok := payloadType(gle, rec.State)
+	if !ok {
+		return GameLogRecord{}, false
+	}
+	p := reflect.New(t).Elem()
+	for i := range t.NumField() {
+		key, optional := eventlog.FieldKey(t.Field(i))
+		f, ok := byKey[key]
+		if !ok {
+			if !optional {
+				return GameLogRecord{}, false
+			}
+			continue
+		}
+		used++
+		if !setPayloadField(p.Field(i), f) {
+			return GameLogRecord{}, false
+		}
+	}
+	if used != len(fields) {
+		return GameLogRecord{}, false
+	}
+	if pi := playerIndexField(t); pi != -1 && p.Field(pi).Int() < 0 {
+		return GameLogRecord{}, false
+	}
+	rec.Payload = p.Interface().(GameLogPayload)
+	return rec, true
+}
+
+// setPayloadField sets a payload field from the logged field, reversing eventlog.WithFields.
+func setPayloadField(v reflect.Value, f eventlog.Field) bool {
+	if v.Kind() == reflect.Pointer {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+	if eventlog.IsLoggableField(v.Type()) {
+		name, ok := f.Value.(string)
+		if !ok {
+			return false
+		}
+		for i, n := range eventlog.EnumNames(v.Type()) {
+			if n == name {
+				v.SetInt(int64(i))
+				return true
+			}
+		}
+		return false
+	}
+	value := reflect.ValueOf(f.Value)
+	if !value.IsValid() || value.Type() != v.Type() {
+		return false
+	}
+	v.Set(value)
+	return true
+}
+
+// playerIndexField returns the index of the payload field holding the player index, or -1.
+func playerIndexField(t reflect.Type) int {
+	for i := range t.NumField() {
+		if key, _ := eventlog.FieldKey(t.Field(i)); key == "player_index" {
+			return i
+		}
+	}
+	return -1
+}
+
+func appendRecord(b []byte, rec GameLogRecord) []byte {
+	p := reflect.ValueOf(rec.Payload)
+	pi := playerIndexField(p.Type())
+	b = append(b, byte(rec.Payload.GameLogEvent()), byte(rec.State))
+	b = binary.AppendUvarint(b, uint64(rec.Round))
+	if pi == -1 {
+		b = binary.AppendUvarint(b, 0)
+	} else {
+		b = binary.AppendUvarint(b, uint64(p.Field(pi).Int()+1))
+	}
+	for i := range p.NumField() {
+		if i != pi {
+			b = appendBinaryValue(b, p.Field(i))
+		}
+	}
+	return b
+}
+
+func appendRawRecord(b []byte, fields []eventlog.Field) []byte {
+	event := make(map[string]any, len(fields))
+	for _, f := range fields {
+		event[f.Key] = f.Value
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		data, _ = json.Marshal(map[string]string{"error": err.Error()})
+	}
+	return append(append(b, rawRecord), data...)
+}
+
+// appendBinaryValue appends the encoding of a payload value.
+func appendBinaryValue(b []byte, v reflect.Value) []byte {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return append(b, 1)
+		}
+		return append(b, 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.AppendVarint(b, v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return binary.AppendUvarint(b, v.Uint())
+	case reflect.String:
+		b = binary.AppendUvarint(b, uint64(v.Len()))
+		return append(b, v.String()...)
+	case reflect.Pointer:
+		if v.IsNil() {
+			return append(b, 0)
+		}
+		return appendBinaryValue(append(b, 1), v.Elem())
+	case reflect.Slice:
+		if v.IsNil() {
+			return append(b, 0)
+		}
+		b = binary.AppendUvarint(b, uint64(v.Len()+1))
+		fallthrough
+	case reflect.Array:
+		for i := range v.Len() {
+			b = appendBinaryValue(b, v.Index(i))
+		}
+		return b
+	case reflect.Map:
+		if v.IsNil() {
+			return append(b, 0)
+		}
+		b = binary.AppendUvarint(b, uint64(v.Len()+1))
+		keys := v.MapKeys()
+		slices.SortFunc(keys, compareMapKeys)
+		for _, k := range keys {
+			b = appendBinaryValue(appendBinaryValue(b, k), v.MapIndex(k))
+		}
+		return b
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				b = appendBinaryValue(b, v.Field(i))
+			}
+		}
+		return b
+	}
+	panic("no binary encoding for type " + v.Type().String())
+}
+
+func compareMapKeys(a, b reflect.Value) int {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	}
+	panic("no order for map keys of type " + a.Type().String())
+}
+
+// binaryDecoder decodes the values of one record body.
+type binaryDecoder struct {
+	data []byte
+}
+
+func (d *binaryDecoder) uvarint() (uint64, error) {
+	v, n := binary.Uvarint(d.data)
+	if n <= 0 {
+		return 0, fmt.Errorf("%w: bad uvarint", ErrInvalidBinaryLog)
+	}
+	d.data = d.data[n:]
+	return v, nil
+}
+
+func (d *binaryDecoder) byte() (byte, error) {
+	if len(d.data) == 0 {
+		return 0, fmt.Errorf("%w: record too short", ErrInvalidBinaryLog)
+	}
+	c := d.data[0]
+	d.data = d.data[1:]
+	return c, nil
+}
+
+// count decodes the count of a slice or map, returning -1 if it is nil.
+func (d *binaryDecoder) count() (int, error) {
+	n, err := d.uvarint()
+	if err != nil {
+		return 0, err
+	}
+	// Each element takes at least one byte, which bounds allocations for corrupt counts
+	if n > uint64(len(d.data))+1 {
+		return 0, fmt.Errorf("%w: count %d exceeds record", ErrInvalidBinaryLog, n-1)
+	}
+	return int(n) - 1, nil
+}
+
+// value decodes a payload value into v, reversing appendBinaryValue.
+func (d *binaryDecoder) value(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Bool:
+		c, err := d.byte()
+		if err != nil {
+			return err
+		}
+		if c > 1 {
+			return fmt.Errorf("%w: bad bool", ErrInvalidBinaryLog)
+		}
+		v.SetBool(c == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, n := binary.Varint(d.data)
+		if n <= 0 {
+			return fmt.Errorf("%w: bad varint", ErrInvalidBinaryLog)
+		}
+		d.data = d.data[n:]
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := d.uvarint()
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.String:
+		n, err := d.uvarint()
+		if err != nil {
+			return err
+		}
+		if n > uint64(len(d.data)) {
+			return fmt.Errorf("%w: string exceeds record", ErrInvalidBinaryLog)
+		}
+		v.SetString(string(d.data[:n]))
+		d.data = d.data[n:]
+	case reflect.Pointer:
+		c, err := d.byte()
+		if err != nil || c == 0 {
+			return err
+		}
+		v.Set(reflect.New(v.Type().Elem()))
+		return d.value(v.Elem())
+	case reflect.Slice:
+		n, err := d.count()
+		if err != nil || n < 0 {
+			return err
+		}
+		v.Set(reflect.MakeSlice(v.Type(), n, n))
+		fallthrough
+	case reflect.Array:
+		for i := range v.Len() {
+			if err := d.value(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		n, err := d.count()
+		if err != nil || n < 0 {
+			return err
+		}
+		v.Set(reflect.MakeMapWithSize(v.Type(), n))
+		for range n {
+			k, e := reflect.New(v.Type().Key()).Elem(), reflect.New(v.Type().Elem()).Elem()
+			if err := d.value(k); err != nil {
+				return err
+			}
+			if err := d.value(e); err != nil {
+				return err
+			}
+			v.SetMapIndex(k, e)
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if !v.Type().Field(i).IsExported() {
+				continue
+			}
+			if err := d.value(v.Field(i)); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("%w: no binary encoding for type %s", ErrInvalidBinaryLog, v.Type())
+	}
+	return nil
+}
+
+// BinaryLogRecord is one record of a binary game log.
+type BinaryLogRecord struct {
+	GameLogRecord
+	Raw eventlog.Event // Set instead of the GameLogRecord for events which weren't game log events
+}
+
+// BinaryLogReader reads the records of a binary game log one at a time.
+type BinaryLogReader struct {
+	r    *bufio.Reader
+	body []byte
+}
+
+// NewBinaryLogReader reads the header of a binary game log, and returns a reader for its records.
+func NewBinaryLogReader(r io.Reader) (*BinaryLogReader, error) {
+	br := bufio.NewReader(r)
+	header := make([]byte, len(binaryLogMagic)+1)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, fmt.Errorf("%w: cannot read header: %w", ErrInvalidBinaryLog, err)
+	}
+	if string(header[:len(binaryLogMagic)]) != binaryLogMagic {
+		return nil, fmt.Errorf("%w: bad magic %q", ErrInvalidBinaryLog, header[:len(binaryLogMagic)])
+	}
+	if v := header[len(binaryLogMagic)]; v != binaryLogVersion {
+		return nil, fmt.Errorf("%w: version %d, want %d", ErrInvalidBinaryLog, v, binaryLogVersion)
+	}
+	return &BinaryLogReader{r: br}, nil
+}
+
+// Next returns the next record of the log, or io.EOF after the last one.
+func (br *BinaryLogReader) Next() (BinaryLogRecord, error) {
+	n, err := binary.ReadUvarint(br.r)
+	if errors.Is(err, io.EOF) {
+		return BinaryLogRecord{}, io.EOF
+	}
+	if err != nil {
+		return BinaryLogRecord{}, fmt.Errorf("%w: cannot read record length: %w", ErrInvalidBinaryLog, err)
+	}
+	if n > 1<<24 {
+		return BinaryLogRecord{}, fmt.Errorf("%w: record length %d too long", ErrInvalidBinaryLog, n)
+	}
+	br.body = slices.Grow(br.body[:0], int(n))[:n]
+	if _, err := io.ReadFull(br.r, br.body); err != nil {
+		return BinaryLogRecord{}, fmt.Errorf("%w: truncated record: %w", ErrInvalidBinaryLog, err)
+	}
+	return decodeRecord(br.body)
+}
+
+func decodeRecord(body []byte) (BinaryLogRecord, error) {
+	d := binaryDecoder{data: body}
+	event, err := d.byte()
+	if err != nil {
+		return BinaryLogRecord{}, err
+	}
+	if event == rawRecord {
+		e, err := eventlog.DecodeEvent(d.data)
+		if err != nil {
+			return BinaryLogRecord{}, fmt.Errorf("%w: raw record: %w", ErrInvalidBinaryLog, err)
+		}
+		return BinaryLogRecord{Raw: e}, nil
+	}
+	state, err := d.byte()
+	if err != nil {
+		return BinaryLogRecord{}, err
+	}
+	round, err := d.uvarint()
+	if err != nil {
+		return BinaryLogRecord{}, err
+	}
+	playerIndex, err := d.uvarint()
+	if err != nil {
+		return BinaryLogRecord{}, err
+	}
+	rec := GameLogRecord{State: StateMachineState(state), Round: int(round)}
+	t, ok := payloadType(GameLogEvent(event), rec.State)
+	if !ok {
+		return BinaryLogRecord{}, fmt.Errorf("%w: event %d in state %d has no payload type", ErrInvalidBinaryLog, event, state)
+	}
+	p := reflect.New(t).Elem()
+	pi := playerIndexField(t)
+	if (pi == -1) != (playerIndex == 0) {
+		return BinaryLogRecord{}, fmt.Errorf("%w: %s record has player index %d", ErrInvalidBinaryLog, t.Name(), playerIndex)
+	}
+	for i := range p.NumField() {
+		if i == pi {
+			p.Field(i).SetInt(int64(playerIndex - 1))
+			continue
+		}
+		if err := d.value(p.Field(i)); err != nil {
+			return BinaryLogRecord{}, fmt.Errorf("%s.%s: %w", t.Name(), t.Field(i).Name, err)
+		}
+	}
+	if len(d.data) != 0 {
+		return BinaryLogRecord{}, fmt.Errorf("%w: %d bytes left over in %s record", ErrInvalidBinaryLog, len(d.data), t.Name())
+	}
+	rec.Payload = p.Interface().(GameLogPayload)
+	return BinaryLogRecord{GameLogRecord: rec}, nil
+}
+
+// errWriter remembers the first error writing to w, and skips later writes.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) Write(p []byte) (int, error) {
+	if ew.err != nil {
+		return 0, ew.err
+	}
+	n, err := ew.w.Write(p)
+	ew.err = err
+	return n, err
+}
+
+// ConvertBinaryLog writes the events of a binary game log to w as a JSON game log, the same as the JSON logger would
+// have written them.
+func ConvertBinaryLog(w io.Writer, r io.Reader) error {
+	br, err := NewBinaryLogReader(r)
+	if err != nil {
+		return err
+	}
+	ew := &errWriter{w: w}
+	logger := eventlog.NewJsonLogger(ew)
+	for ew.err == nil {
+		rec, err := br.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if rec.Raw == nil {
+			rec.logTo(logger)
+			continue
+		}
+		data, err := json.Marshal(rec.Raw)
+		if err != nil {
+			return err
+		}
+		ew.Write(append(data, '\n'))
+	}
+	return ew.err
+}
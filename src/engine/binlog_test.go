@@ -0,0 +1,205 @@
+package engine
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	randv2 "math/rand/v2"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/WillMorrison/JouleQuestCardGame/assets"
+	"github.com/WillMorrison/JouleQuestCardGame/core"
+	"github.com/WillMorrison/JouleQuestCardGame/eventlog"
+	"github.com/WillMorrison/JouleQuestCardGame/params"
+)
+
+func newTestBinaryLogger(t *testing.T, w io.Writer) eventlog.Logger {
+	t.Helper()
+	logger, err := NewBinaryLogger(w)
+	if err != nil {
+		t.Fatalf("NewBinaryLogger() error = %v", err)
+	}
+	return logger
+}
+
+func Test_ConvertBinaryLog_MatchesJsonLog(t *testing.T) {
+	// Arrange: log games to both formats, including events the binary format has no record for
+	var jsonBuf, binBuf bytes.Buffer
+	logger := eventlog.NewTeeLogger(eventlog.NewJsonLogger(&jsonBuf), newTestBinaryLogger(t, &binBuf))
+	for seed := range uint64(3) {
+		pgs, err := NewSeededProceduralGame(3, params.Default, seed, logger.Sub())
+		if err != nil {
+			t.Fatalf("Couldn't create new game: %s", err)
+		}
+		playRandomActions(pgs, randv2.New(randv2.NewPCG(seed, 2)), 1000)
+	}
+	logger.Event().WithKey("note", "not a game event").Log()
+	logger.Sub().SetKey("extra", 1).Event().With(GameLogEventGlobalWin, StateMachineStateOperatePhase).Log()
+
+	binSize := binBuf.Len()
+
+	// Act
+	var converted bytes.Buffer
+	err := ConvertBinaryLog(&converted, &binBuf)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ConvertBinaryLog() error = %v", err)
+	}
+	if converted.String() != jsonBuf.String() {
+		t.Errorf("Converted log differs:\ngot  %s\nwant %s", converted.String(), jsonBuf.String())
+	}
+	if binSize > jsonBuf.Len()/4 {
+		t.Errorf("Binary log is %d bytes, want less than a quarter of the %d byte JSON log", binSize, jsonBuf.Len())
+	}
+}
+
+func Test_BinaryLogger_ConcurrentGames(t *testing.T) {
+	var binBuf bytes.Buffer
+	logger := newTestBinaryLogger(t, &binBuf)
+	var wg sync.WaitGroup
+	for seed := range uint64(4) {
+		pgs, err := NewSeededProceduralGame(2, params.Default, seed, logger.Sub())
+		if err != nil {
+			t.Fatalf("Couldn't create new game: %s", err)
+		}
+		wg.Go(func() { playRandomActions(pgs, randv2.New(randv2.NewPCG(seed, 2)), 1000) })
+	}
+	wg.Wait()
+
+	if err := ConvertBinaryLog(io.Discard, &binBuf); err != nil {
+		t.Errorf("ConvertBinaryLog() error = %v", err)
+	}
+}
+
+func Test_BinaryLogReader_Next(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestBinaryLogger(t, &buf)
+	want := samplePayloads()
+	for _, rec := range want {
+		rec.logTo(logger)
+	}
+	logger.Event().WithKey("note", "raw").Log()
+	r, err := NewBinaryLogReader(&buf)
+	if err != nil {
+		t.Fatalf("NewBinaryLogReader() error = %v", err)
+	}
+
+	for _, w := range want {
+		got, err := r.Next()
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		if got.Raw != nil || !reflect.DeepEqual(got.GameLogRecord, w) {
+			t.Errorf("Next() = %+v, want %+v", got, w)
+		}
+	}
+	raw, err := r.Next()
+	if err != nil || raw.Raw.Text("note") != "raw" {
+		t.Errorf("Next() = %+v, %v, want the raw event", raw, err)
+	}
+	if _, err := r.Next(); !errors.Is(err, io.EOF) {
+		t.Errorf("Next() at the end error = %v, want io.EOF", err)
+	}
+}
+
+func Test_BinaryLogReader_Invalid(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestBinaryLogger(t, &buf)
+	logPayload(logger.Event().With(StateMachineStateBuildPhase), PlayerActionEvent{})
+	valid := buf.Bytes()
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "bad magic", data: append([]byte("NOPE"), valid[4:]...)},
+		{name: "other version", data: append(append([]byte(binaryLogMagic), binaryLogVersion+1), valid[5:]...)},
+		{name: "truncated record", data: valid[:len(valid)-1]},
+		{name: "unknown event", data: append(bytes.Clone(valid[:5]), 4, 0x7f, 0, 0, 0)},
+		{name: "left over bytes", data: append(append(bytes.Clone(valid[:5]), valid[5]+1), append(bytes.Clone(valid[6:]), 0)...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ConvertBinaryLog(io.Discard, bytes.NewReader(tt.data))
+
+			if !errors.Is(err, ErrInvalidBinaryLog) {
+				t.Errorf("ConvertBinaryLog() error = %v, want %v", err, ErrInvalidBinaryLog)
+			}
+		})
+	}
+}
+
+// Binary logs store enums as numbers, so existing values must keep their numbers. New values may be appended to these
+// lists; any other change needs a new binaryLogVersion.
+var frozenBinaryLogEnums = map[reflect.Type][]string{
+	reflect.TypeFor[GameLogEvent](): {
+		"StateMachineTransition", "PlayerAction", "PlayerActionInvalid", "EventDrawn", "GridOutcome", "MarketOutcome",
+		"CarbonTaxApplied", "PlayerLoses", "EveryoneLoses", "GlobalWin", "PlayerActionUndone",
+	},
+	reflect.TypeFor[StateMachineState](): {
+		"StateMachineStateGameStart", "StateMachineStateBuildPhase", "StateMachineStateOperatePhase",
+		"StateMachineStateGameEnd",
+	},
+	reflect.TypeFor[ActionType](): {
+		"BuildAsset", "ScrapAsset", "TakeoverAsset", "TakeoverScrapAsset", "PledgeCapacity", "Finished",
+	},
+	reflect.TypeFor[assets.Type]():          {"Renewable", "Fossil", "Battery"},
+	reflect.TypeFor[core.GameStatus]():      {"Ongoing", "Win", "Loss"},
+	reflect.TypeFor[core.PlayerStatus]():    {"Active", "Lost"},
+	reflect.TypeFor[core.EventRisk]():       {"Low", "Medium", "High"},
+	reflect.TypeFor[core.PriceVolatility](): {"Low", "Medium", "High", "Extreme"},
+	reflect.TypeFor[core.GridStability]():   {"Dangerous", "Bad", "Ok", "Good"},
+	reflect.TypeFor[core.LossCondition](): {
+		"None", "PlayerBankrupt", "LastPlayerWithFossilAssets", "GridUnstable", "InsufficientGeneration",
+		"CarbonEmissionsExceeded", "NoActivePlayers", "UnownedTakeoverAssets",
+	},
+	reflect.TypeFor[params.CapacityRule]():             {"PaymentPerAsset", "NoCapacityMarket", "SharedCapacityPaymentPool"},
+	reflect.TypeFor[params.CarbonTaxRule]():            {"NoCarbonTax", "ApplyCarbonTax"},
+	reflect.TypeFor[params.WinConditionRule]():         {"LastFossilLoses", "RenewablePenetrationThreshold"},
+	reflect.TypeFor[params.GenerationConstraintRule](): {"Minimum", "MaxDecrease"},
+	reflect.TypeFor[params.TakeoverRule]():             {"ForcedTakeover", "VirtualOwner"},
+}
+
+func Test_BinaryLog_EnumValuesFrozen(t *testing.T) {
+	for typ, want := range frozenBinaryLogEnums {
+		got := eventlog.EnumNames(typ)
+		if len(got) < len(want) || !slices.Equal(got[:len(want)], want) {
+			t.Errorf("%s values = %q, want %q followed by any new values", typ, got, want)
+		}
+	}
+
+	// Every enum stored in a record must be pinned above
+	seen := map[reflect.Type]bool{}
+	var walk func(reflect.Type)
+	walk = func(typ reflect.Type) {
+		if seen[typ] {
+			return
+		}
+		seen[typ] = true
+		switch typ.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array:
+			walk(typ.Elem())
+		case reflect.Map:
+			walk(typ.Key())
+			walk(typ.Elem())
+		case reflect.Struct:
+			for i := range typ.NumField() {
+				if typ.Field(i).IsExported() {
+					walk(typ.Field(i).Type)
+				}
+			}
+		case reflect.Int:
+			if _, ok := frozenBinaryLogEnums[typ]; !ok && typ.Implements(reflect.TypeFor[fmt.Stringer]()) {
+				t.Errorf("Binary log records store enum %s, which isn't in frozenBinaryLogEnums", typ)
+			}
+		}
+	}
+	for _, p := range gameLogPayloads {
+		walk(reflect.TypeOf(p))
+	}
+}
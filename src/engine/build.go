@@ -66,7 +66,7 @@ func BuildPhase(gs *GameState) StateRunner {
 	gs.Round++
 	gs.Logger = gs.Logger.SetKey("round", gs.Round) // Always add round info to game event logs
 	logger := gs.Logger.Sub().Set(StateMachineStateBuildPhase)
-	logger.Event().With(GameLogEventStateMachineTransition).Log()
+	logPayload(logger.Event(), PhaseStartEvent{})
 
 	var numBuildingPlayers int
 	for _, p := range gs.activePlayers() {
@@ -85,10 +85,10 @@ func BuildPhase(gs *GameState) StateRunner {
 				for _, p := range gs.Players {
 					money = append(money, p.Money)
 				}
-				logger.Event().With(GameLogEventEveryoneLoses, gs.Reason).WithKey("takeover_pool", gs.TakeoverPool).WithKey("player_funds", money).Log()
+				logPayload(logger.Event(), EveryoneLosesEvent{Reason: gs.Reason, TakeoverPool: &gs.TakeoverPool, PlayerFunds: money})
 			} else {
 				gs.SetGlobalLossWithReason(core.LossConditionNoActivePlayers) // Should never happen, but if it does, force a game loss
-				logger.Event().With(GameLogEventEveryoneLoses, gs.Reason).Log()
+				logPayload(logger.Event(), EveryoneLosesEvent{Reason: gs.Reason})
 			}
 			return GameEnd
 		}
@@ -96,10 +96,10 @@ func BuildPhase(gs *GameState) StateRunner {
 		chosenAction := gs.GetPlayerAction(actions)
 		err := gs.applyPlayerAction(chosenAction)
 		if err != nil {
-			logger.Event().With(GameLogEventPlayerActionInvalid).WithKey("invalid_action", chosenAction).WithKey("error", err.Error()).Log()
+			logPayload(logger.Event(), PlayerActionInvalidEvent{Action: chosenAction, Error: err.Error()})
 			continue
 		} else {
-			logger.Event().With(GameLogEventPlayerAction).WithKey("action", chosenAction).Log()
+			logPayload(logger.Event(), PlayerActionEvent{Action: chosenAction})
 		}
 		if chosenAction.Type == ActionTypeFinished {
 			numBuildingPlayers -= 1
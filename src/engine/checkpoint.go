@@ -0,0 +1,228 @@
+// This file contains checkpointing (serialization) of procedural game state
+
+package engine
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/WillMorrison/JouleQuestCardGame/assets"
+	"github.com/WillMorrison/JouleQuestCardGame/core"
+	"github.com/WillMorrison/JouleQuestCardGame/eventlog"
+	"github.com/WillMorrison/JouleQuestCardGame/params"
+)
+
+// checkpointVersion is incremented whenever the checkpoint layout changes incompatibly.
+const checkpointVersion = 2
+
+var ErrInvalidCheckpoint = errors.New("invalid game checkpoint")
+
+type playerCheckpoint struct {
+	Status     core.PlayerStatus
+	Reason     core.LossCondition
+	Money      int
+	Assets     assets.AssetMix
+	IsBuilding bool
+}
+
+type undoCheckpoint struct {
+	Action       PlayerAction
+	Player       playerCheckpoint
+	TakeoverPool assets.AssetMix
+}
+
+func playerToCheckpoint(p PlayerState) playerCheckpoint {
+	return playerCheckpoint{
+		Status:     p.Status,
+		Reason:     p.Reason,
+		Money:      p.Money,
+		Assets:     p.Assets,
+		IsBuilding: p.isBuilding,
+	}
+}
+
+func (p playerCheckpoint) playerState() PlayerState {
+	return PlayerState{
+		Status:     p.Status,
+		Reason:     p.Reason,
+		Money:      p.Money,
+		Assets:     p.Assets,
+		isBuilding: p.IsBuilding,
+	}
+}
+
+// rewardCheckpoint is the part of the state before the last applied action that rewards are computed from.
+type rewardCheckpoint struct {
+	Status          core.GameStatus
+	CarbonEmissions int
+	Players         []playerCheckpoint
+	LastSnapshot    Snapshot
+}
+
+// checkpoint is the serialized form of a ProceduralGameState. Unlike the GameState JSON it stores enums as integers
+// and includes unexported state, so that it round-trips exactly.
+type checkpoint struct {
+	Version         int
+	State           StateMachineState
+	Status          core.GameStatus
+	Reason          core.LossCondition
+	Round           int
+	CarbonEmissions int
+	Players         []playerCheckpoint
+	TakeoverPool    assets.AssetMix
+	LastSnapshot    Snapshot
+	Params          params.Params
+	RNGSeed         uint64
+	RNG             []byte           // Binary encoding of the PCG state
+	UndoHistory     []undoCheckpoint `json:",omitempty"`
+	RewardBaseline  rewardCheckpoint // So that rewards for the last applied action survive a round trip
+}
+
+func (pgs ProceduralGameState) checkpoint() (checkpoint, error) {
+	rng, err := pgs.gs.pcg.MarshalBinary()
+	if err != nil {
+		return checkpoint{}, err
+	}
+	cp := checkpoint{
+		Version:         checkpointVersion,
+		State:           pgs.s,
+		Status:          pgs.gs.Status,
+		Reason:          pgs.gs.Reason,
+		Round:           pgs.gs.Round,
+		CarbonEmissions: pgs.gs.CarbonEmissions,
+		TakeoverPool:    pgs.gs.TakeoverPool,
+		LastSnapshot:    pgs.gs.LastSnapshot,
+		Params:          pgs.gs.Params,
+		RNGSeed:         pgs.gs.rngSeed,
+		RNG:             rng,
+		RewardBaseline: rewardCheckpoint{
+			Status:          pgs.prev.Status,
+			CarbonEmissions: pgs.prev.CarbonEmissions,
+			LastSnapshot:    pgs.prev.LastSnapshot,
+		},
+	}
+	for _, p := range pgs.gs.Players {
+		cp.Players = append(cp.Players, playerToCheckpoint(p))
+	}
+	for _, p := range pgs.prev.Players {
+		cp.RewardBaseline.Players = append(cp.RewardBaseline.Players, playerToCheckpoint(p))
+	}
+	for _, u := range pgs.history {
+		cp.UndoHistory = append(cp.UndoHistory, undoCheckpoint{Action: u.action, Player: playerToCheckpoint(u.player), TakeoverPool: u.takeoverPool})
+	}
+	return cp, nil
+}
+
+// restore replaces the game state with the checkpointed state. The logger is kept, and the callbacks are cleared.
+func (pgs *ProceduralGameState) restore(cp checkpoint) error {
+	if cp.Version != checkpointVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidCheckpoint, cp.Version)
+	}
+	if cp.State < StateMachineStateGameStart || cp.State > StateMachineStateGameEnd {
+		return fmt.Errorf("%w: unknown state machine state %d", ErrInvalidCheckpoint, cp.State)
+	}
+	if err := cp.Params.Valid(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCheckpoint, err)
+	}
+	if _, ok := cp.Params.StartingFossilAssetsPerPlayer[len(cp.Players)]; !ok {
+		return fmt.Errorf("%w: invalid number of players: %d", ErrInvalidCheckpoint, len(cp.Players))
+	}
+
+	gs := GameState{
+		Status:          cp.Status,
+		Reason:          cp.Reason,
+		Round:           cp.Round,
+		CarbonEmissions: cp.CarbonEmissions,
+		TakeoverPool:    cp.TakeoverPool,
+		LastSnapshot:    cp.LastSnapshot,
+		Params:          cp.Params,
+		Logger:          pgs.gs.Logger,
+		rngSeed:         cp.RNGSeed,
+	}
+	if err := gs.pcg.UnmarshalBinary(cp.RNG); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCheckpoint, err)
+	}
+	for _, p := range cp.Players {
+		gs.Players = append(gs.Players, p.playerState())
+	}
+	if len(cp.UndoHistory) > core.MaxUndo {
+		return fmt.Errorf("%w: undo history has %d actions, at most %d are kept", ErrInvalidCheckpoint, len(cp.UndoHistory), core.MaxUndo)
+	}
+	var history []undoEntry
+	for _, u := range cp.UndoHistory {
+		if u.Action.PlayerIndex < 0 || u.Action.PlayerIndex >= len(gs.Players) {
+			return fmt.Errorf("%w: undo history has invalid player index %d", ErrInvalidCheckpoint, u.Action.PlayerIndex)
+		}
+		history = append(history, undoEntry{action: u.Action, player: u.Player.playerState(), takeoverPool: u.TakeoverPool})
+	}
+	if len(cp.RewardBaseline.Players) != len(gs.Players) {
+		return fmt.Errorf("%w: reward baseline has %d players, want %d", ErrInvalidCheckpoint, len(cp.RewardBaseline.Players), len(gs.Players))
+	}
+	if gs.Logger == nil {
+		gs.Logger = eventlog.NullLogger{}
+	}
+	gs.Logger = gs.Logger.SetKey("round", gs.Round)
+
+	pgs.s = cp.State
+	pgs.gs = gs
+	pgs.history = history
+	pgs.prev = pgs.gs.copyForReward()
+	pgs.prev.Status = cp.RewardBaseline.Status
+	pgs.prev.CarbonEmissions = cp.RewardBaseline.CarbonEmissions
+	pgs.prev.LastSnapshot = cp.RewardBaseline.LastSnapshot
+	for i, p := range cp.RewardBaseline.Players {
+		pgs.prev.Players[i] = p.playerState()
+	}
+	return nil
+}
+
+// SetLogger replaces the logger used for subsequent game events, e.g. after restoring a checkpoint.
+func (pgs *ProceduralGameState) SetLogger(logger eventlog.Logger) {
+	pgs.gs.Logger = logger.SetKey("round", pgs.gs.Round)
+}
+
+// MarshalBinary encodes the full game state, including the RNG state, so that it can be restored with UnmarshalBinary.
+// The logger is not included.
+func (pgs ProceduralGameState) MarshalBinary() ([]byte, error) {
+	cp, err := pgs.checkpoint()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(cp); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary restores a game state encoded by MarshalBinary. The current logger is kept, or a NullLogger is used
+// if there is none.
+func (pgs *ProceduralGameState) UnmarshalBinary(data []byte) error {
+	var cp checkpoint
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&cp); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCheckpoint, err)
+	}
+	return pgs.restore(cp)
+}
+
+// MarshalJSON encodes the full game state in the same form as MarshalBinary, but as JSON.
+func (pgs ProceduralGameState) MarshalJSON() ([]byte, error) {
+	cp, err := pgs.checkpoint()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(cp)
+}
+
+// UnmarshalJSON restores a game state encoded by MarshalJSON. The current logger is kept, or a NullLogger is used
+// if there is none.
+func (pgs *ProceduralGameState) UnmarshalJSON(data []byte) error {
+	var cp checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCheckpoint, err)
+	}
+	return pgs.restore(cp)
+}
@@ -0,0 +1,141 @@
+package engine
+
+import (
+	"bytes"
+	"errors"
+	randv2 "math/rand/v2"
+	"reflect"
+	"testing"
+
+	"github.com/WillMorrison/JouleQuestCardGame/assets"
+	"github.com/WillMorrison/JouleQuestCardGame/core"
+	"github.com/WillMorrison/JouleQuestCardGame/eventlog"
+	"github.com/WillMorrison/JouleQuestCardGame/params"
+)
+
+// playRandomActions applies up to n random possible actions to the game, stopping early if the game ends.
+func playRandomActions(pgs *ProceduralGameState, rng *randv2.Rand, n int) {
+	for range n {
+		actions := pgs.PossibleActions()
+		if len(actions) == 0 {
+			return
+		}
+		pgs.ApplyPlayerAction(actions[rng.IntN(len(actions))])
+	}
+}
+
+func Test_ProceduralGameState_Checkpoint_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name      string
+		marshal   func(*ProceduralGameState) ([]byte, error)
+		unmarshal func(*ProceduralGameState, []byte) error
+	}{
+		{"binary", (*ProceduralGameState).MarshalBinary, (*ProceduralGameState).UnmarshalBinary},
+		{"json", (*ProceduralGameState).MarshalJSON, (*ProceduralGameState).UnmarshalJSON},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange: play part of a game, so that the checkpoint is mid build phase after some operate phases
+			var origLog, restoredLog bytes.Buffer
+			orig, err := NewProceduralGame(3, params.Default, eventlog.NewJsonLogger(&origLog))
+			if err != nil {
+				t.Fatalf("Couldn't create new game: %s", err)
+			}
+			orig.SetRNGSeed(42)
+			playRandomActions(orig, randv2.New(randv2.NewPCG(1, 2)), 20)
+
+			// Act
+			data, err := tt.marshal(orig)
+			if err != nil {
+				t.Fatalf("Marshal failed: %s", err)
+			}
+			restored := &ProceduralGameState{}
+			if err := tt.unmarshal(restored, data); err != nil {
+				t.Fatalf("Unmarshal failed: %s", err)
+			}
+			restored.SetLogger(eventlog.NewJsonLogger(&restoredLog))
+
+			// Assert: the restored game is identical, and continues identically
+			if !reflect.DeepEqual(orig.Game(), restored.Game()) || orig.s != restored.s {
+				t.Fatalf("Restored game differs:\ngot  %+v\nwant %+v", restored.Game(), orig.Game())
+			}
+			for _, scheme := range []core.RewardScheme{core.RewardSchemeTerminal, core.RewardSchemeMoneyDelta, core.RewardSchemeShaped} {
+				if got, want := restored.Rewards(scheme), orig.Rewards(scheme); !reflect.DeepEqual(got, want) {
+					t.Errorf("Restored Rewards(%s) = %v, want %v", scheme, got, want)
+				}
+			}
+			origLog.Reset()
+			playRandomActions(orig, randv2.New(randv2.NewPCG(3, 4)), 100)
+			playRandomActions(restored, randv2.New(randv2.NewPCG(3, 4)), 100)
+			if !reflect.DeepEqual(orig.Game(), restored.Game()) || orig.s != restored.s {
+				t.Errorf("Restored game diverged:\ngot  %+v\nwant %+v", restored.Game(), orig.Game())
+			}
+			if origLog.String() != restoredLog.String() {
+				t.Errorf("Restored game logs diverged:\ngot  %s\nwant %s", restoredLog.String(), origLog.String())
+			}
+		})
+	}
+}
+
+func Test_ProceduralGameState_Checkpoint_KeepsRewards(t *testing.T) {
+	// Arrange: the last action costs money, so it has a money delta reward
+	orig, err := NewProceduralGame(2, params.Default, eventlog.NullLogger{})
+	if err != nil {
+		t.Fatalf("Couldn't create new game: %s", err)
+	}
+	orig.ApplyPlayerAction(PlayerAction{Type: ActionTypeBuildAsset, PlayerIndex: 0, AssetType: assets.TypeRenewable, Cost: params.Default.BuildCost(assets.TypeRenewable)})
+
+	// Act
+	data, err := orig.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %s", err)
+	}
+	restored := &ProceduralGameState{}
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %s", err)
+	}
+
+	// Assert
+	if got, want := restored.Rewards(core.RewardSchemeMoneyDelta), orig.Rewards(core.RewardSchemeMoneyDelta); !reflect.DeepEqual(got, want) || want[0] == 0 {
+		t.Errorf("Restored Rewards() = %v, want %v", got, want)
+	}
+}
+
+func Test_ProceduralGameState_UnmarshalJSON_Invalid(t *testing.T) {
+	orig, err := NewProceduralGame(2, params.Default, eventlog.NullLogger{})
+	if err != nil {
+		t.Fatalf("Couldn't create new game: %s", err)
+	}
+	cp, err := orig.checkpoint()
+	if err != nil {
+		t.Fatalf("checkpoint failed: %s", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*checkpoint)
+	}{
+		{"unknown version", func(cp *checkpoint) { cp.Version = 0 }},
+		{"unknown state", func(cp *checkpoint) { cp.State = StateMachineStateGameEnd + 1 }},
+		{"invalid params", func(cp *checkpoint) { cp.Params.InitialCash = -1 }},
+		{"invalid player count", func(cp *checkpoint) { cp.Players = cp.Players[:1] }},
+		{"invalid rng", func(cp *checkpoint) { cp.RNG = nil }},
+		{"invalid reward baseline", func(cp *checkpoint) { cp.RewardBaseline.Players = nil }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			bad := cp
+			bad.Players = append([]playerCheckpoint(nil), cp.Players...)
+			tt.modify(&bad)
+
+			// Act
+			err := (&ProceduralGameState{}).restore(bad)
+
+			// Assert
+			if !errors.Is(err, ErrInvalidCheckpoint) {
+				t.Errorf("restore() = %v, want %v", err, ErrInvalidCheckpoint)
+			}
+		})
+	}
+}
@@ -1,10 +1,14 @@
 package engine
 
-import "github.com/WillMorrison/JouleQuestCardGame/eventlog"
+import (
+	"log/slog"
+
+	"github.com/WillMorrison/JouleQuestCardGame/eventlog"
+)
 
 type GameLogEvent int
 
-var _ eventlog.Loggable = GameLogEvent(0)
+var _ eventlog.Leveled = GameLogEvent(0)
 
 //go:generate go tool stringer -type=GameLogEvent -trimprefix=GameLogEvent
 const (
@@ -25,8 +29,23 @@ const (
 	GameLogEventPlayerLoses
 	GameLogEventEveryoneLoses
 	GameLogEventGlobalWin
+
+	// Appended so that the values above keep the numbers persisted in logs
+	GameLogEventPlayerActionUndone
 )
 
 func (gle GameLogEvent) LogKey() string {
 	return "game_event"
 }
+
+// Level returns the slog level of the event. Steps of play are debug events, wins and losses are info events, and
+// invalid actions are warnings.
+func (gle GameLogEvent) Level() slog.Level {
+	switch gle {
+	case GameLogEventPlayerActionInvalid:
+		return slog.LevelWarn
+	case GameLogEventPlayerLoses, GameLogEventEveryoneLoses, GameLogEventGlobalWin:
+		return slog.LevelInfo
+	}
+	return slog.LevelDebug
+}
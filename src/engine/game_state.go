@@ -46,6 +46,26 @@ func (ps PlayerState) MarshalJSON() ([]byte, error) {
 	return json.Marshal(psj)
 }
 
+// UnmarshalJSON decodes the JSON form of a player, as logged in GameEnd events.
+func (ps *PlayerState) UnmarshalJSON(data []byte) error {
+	var psj playerStateJSON
+	if err := json.Unmarshal(data, &psj); err != nil {
+		return err
+	}
+	status, err := eventlog.ParseEnum[core.PlayerStatus](psj.Status)
+	if err != nil {
+		return err
+	}
+	reason := core.LossConditionNone
+	if psj.Reason != "" {
+		if reason, err = eventlog.ParseEnum[core.LossCondition](psj.Reason); err != nil {
+			return err
+		}
+	}
+	*ps = PlayerState{Status: status, Reason: reason, Money: psj.Money, Assets: psj.Assets}
+	return nil
+}
+
 // Returns whether the player owns any fossil assets
 func (ps PlayerState) HasFossilAssets() bool {
 	return ps.Assets.AssetsOfType(assets.TypeFossil) > 0
@@ -85,7 +105,8 @@ type GameState struct {
 	GameOverFunc    func()          // Callback function which is called when the game ends.
 
 	// RNG for operate-phase randomness
-	pcg randv2.PCG
+	pcg     randv2.PCG
+	rngSeed uint64 // The seed last passed to SetRNGSeed, so that it can be logged for replays
 }
 
 // getAssetMix returns the total asset mix of all active players and the takeover pool
@@ -126,6 +147,7 @@ func (gs *GameState) movePlayerAssetsToTakeoverPool(pi int) {
 func (gs *GameState) SetRNGSeed(seed uint64) {
 	// The seed is used directly, the stream index is fixed to 0.
 	gs.pcg.Seed(seed, 0)
+	gs.rngSeed = seed
 }
 
 // NewGame returns a new GameState ready to play
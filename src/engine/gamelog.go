@@ -0,0 +1,228 @@
+// This file defines the typed payloads of game log events
+
+package engine
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+
+	"github.com/WillMorrison/JouleQuestCardGame/assets"
+	"github.com/WillMorrison/JouleQuestCardGame/core"
+	"github.com/WillMorrison/JouleQuestCardGame/eventlog"
+	"github.com/WillMorrison/JouleQuestCardGame/params"
+)
+
+// GameLogPayload is the payload of one GameLogEvent. Its fields are logged under the keys of their `log` tags, as
+// described by eventlog.WithFields, next to the event's game_event key.
+type GameLogPayload interface {
+	GameLogEvent() GameLogEvent
+}
+
+// GameStartEvent is the StateMachineTransition event into StateMachineStateGameStart.
+type GameStartEvent struct {
+	Params     params.Params `log:"game_parameters"`
+	NumPlayers int           `log:"num_players"`
+	RNGSeed    uint64        `log:"rng_seed"`
+}
+
+// PhaseStartEvent is the StateMachineTransition event into the build or operate phase.
+type PhaseStartEvent struct{}
+
+// GameEndEvent is the StateMachineTransition event into StateMachineStateGameEnd.
+type GameEndEvent struct {
+	Status         core.GameStatus    `log:"game_status"`
+	Reason         core.LossCondition `log:"loss_reason"` // LossConditionNone if the game was won
+	TotalEmissions int                `log:"total_emissions"`
+	Players        []PlayerState      `log:"players"`
+}
+
+type PlayerActionEvent struct {
+	Action PlayerAction `log:"action"`
+}
+
+type PlayerActionInvalidEvent struct {
+	Action PlayerAction `log:"invalid_action"`
+	Error  string       `log:"error"`
+}
+
+type PlayerActionUndoneEvent struct {
+	Action PlayerAction `log:"action"`
+}
+
+type EventDrawnEvent struct {
+	Risk core.EventRisk `log:"event_risk"`
+}
+
+type GridOutcomeEvent struct {
+	GridOutcome  Snapshot `log:"grid_outcome"`
+	NewEmissions int      `log:"new_emissions"`
+}
+
+type MarketOutcomeEvent struct {
+	PlayerIndex int             `log:"player_index"`
+	AssetMix    assets.AssetMix `log:"player_asset_mix"`
+	PnL         int             `log:"player_PnL"`
+	Money       int             `log:"player_money"` // After adding PnL
+}
+
+// CarbonTaxAppliedEvent is reserved for the carbon tax rule, and not logged yet.
+type CarbonTaxAppliedEvent struct{}
+
+type PlayerLosesEvent struct {
+	PlayerIndex int                `log:"player_index"`
+	Reason      core.LossCondition `log:"loss_reason"`
+	Money       *int               `log:"player_money"` // Only set for bankrupt players
+}
+
+// EveryoneLosesEvent reports a global loss. Besides the reason, it has the fields explaining that reason.
+type EveryoneLosesEvent struct {
+	Reason           core.LossCondition  `log:"loss_reason"`
+	GenerationAssets *int                `log:"generation_assets"` // InsufficientGeneration
+	GridStability    *core.GridStability `log:"grid_stability"`    // GridUnstable
+	Risk             *core.EventRisk     `log:"event_risk"`        // GridUnstable
+	TotalEmissions   *int                `log:"total_emissions"`   // CarbonEmissionsExceeded
+	NewEmissions     *int                `log:"new_emissions"`     // CarbonEmissionsExceeded
+	TakeoverPool     *assets.AssetMix    `log:"takeover_pool"`     // UnownedTakeoverAssets
+	PlayerFunds      []int               `log:"player_funds"`      // UnownedTakeoverAssets
+}
+
+type GlobalWinEvent struct{}
+
+func (GameStartEvent) GameLogEvent() GameLogEvent           { return GameLogEventStateMachineTransition }
+func (PhaseStartEvent) GameLogEvent() GameLogEvent          { return GameLogEventStateMachineTransition }
+func (GameEndEvent) GameLogEvent() GameLogEvent             { return GameLogEventStateMachineTransition }
+func (PlayerActionEvent) GameLogEvent() GameLogEvent        { return GameLogEventPlayerAction }
+func (PlayerActionInvalidEvent) GameLogEvent() GameLogEvent { return GameLogEventPlayerActionInvalid }
+func (PlayerActionUndoneEvent) GameLogEvent() GameLogEvent  { return GameLogEventPlayerActionUndone }
+func (EventDrawnEvent) GameLogEvent() GameLogEvent          { return GameLogEventEventDrawn }
+func (GridOutcomeEvent) GameLogEvent() GameLogEvent         { return GameLogEventGridOutcome }
+func (MarketOutcomeEvent) GameLogEvent() GameLogEvent       { return GameLogEventMarketOutcome }
+func (CarbonTaxAppliedEvent) GameLogEvent() GameLogEvent    { return GameLogEventCarbonTaxApplied }
+func (PlayerLosesEvent) GameLogEvent() GameLogEvent         { return GameLogEventPlayerLoses }
+func (EveryoneLosesEvent) GameLogEvent() GameLogEvent       { return GameLogEventEveryoneLoses }
+func (GlobalWinEvent) GameLogEvent() GameLogEvent           { return GameLogEventGlobalWin }
+
+// gameLogPayloads has the zero value of every payload type, in the order of their GameLogEvent.
+var gameLogPayloads = []GameLogPayload{
+	GameStartEvent{},
+	PhaseStartEvent{},
+	GameEndEvent{},
+	PlayerActionEvent{},
+	PlayerActionInvalidEvent{},
+	PlayerActionUndoneEvent{},
+	EventDrawnEvent{},
+	GridOutcomeEvent{},
+	MarketOutcomeEvent{},
+	CarbonTaxAppliedEvent{},
+	PlayerLosesEvent{},
+	EveryoneLosesEvent{},
+	GlobalWinEvent{},
+}
+
+// KeepTerminalEvents is an eventlog.EventFilter keeping the events which report how a game ended: player and global
+// losses, the global win, and the transition to GameEnd.
+var KeepTerminalEvents = eventlog.KeepValues(
+	GameLogEventPlayerLoses,
+	GameLogEventEveryoneLoses,
+	GameLogEventGlobalWin,
+	StateMachineStateGameEnd,
+)
+
+// logPayload adds the payload to the event, and logs it.
+func logPayload(e eventlog.LogEvent, p GameLogPayload) {
+	eventlog.WithFields(e.With(p.GameLogEvent()), p).Log()
+}
+
+// payloadStates returns the states a transition payload is logged in, or nil for payloads of other events.
+func payloadStates(p GameLogPayload) []StateMachineState {
+	switch p.(type) {
+	case GameStartEvent:
+		return []StateMachineState{StateMachineStateGameStart}
+	case PhaseStartEvent:
+		return []StateMachineState{StateMachineStateBuildPhase, StateMachineStateOperatePhase}
+	case GameEndEvent:
+		return []StateMachineState{StateMachineStateGameEnd}
+	}
+	return nil
+}
+
+// GameLogRecord is one decoded event of a JSON game log. The keys that the engine sets on every event of a state or
+// round are decoded next to the payload.
+type GameLogRecord struct {
+	State   StateMachineState `log:"state"`
+	Round   int               `log:"round,optional"` // 0 for the GameStart event, which comes before the first round
+	Payload GameLogPayload
+}
+
+// DecodeGameLogRecord decodes an event of a JSON game log.
+func DecodeGameLogRecord(e eventlog.Event) (GameLogRecord, error) {
+	var rec GameLogRecord
+	if err := e.DecodeFields(&rec); err != nil {
+		return GameLogRecord{}, err
+	}
+	gle, err := eventlog.ParseEnum[GameLogEvent](e.Text(GameLogEventStateMachineTransition.LogKey()))
+	if err != nil {
+		return GameLogRecord{}, err
+	}
+	t, ok := payloadType(gle, rec.State)
+	if !ok {
+		return GameLogRecord{}, fmt.Errorf("%s event in state %s has no payload type", gle, rec.State)
+	}
+	p := reflect.New(t)
+	if err := e.DecodeFields(p.Interface()); err != nil {
+		return GameLogRecord{}, fmt.Errorf("%s: %w", gle, err)
+	}
+	rec.Payload = p.Elem().Interface().(GameLogPayload)
+	return rec, nil
+}
+
+// payloadType returns the type of the payload of events of gle logged in the state.
+func payloadType(gle GameLogEvent, state StateMachineState) (reflect.Type, bool) {
+	for _, zero := range gameLogPayloads {
+		if zero.GameLogEvent() != gle {
+			continue
+		}
+		if states := payloadStates(zero); states != nil && !slices.Contains(states, state) {
+			continue
+		}
+		return reflect.TypeOf(zero), true
+	}
+	return nil, false
+}
+
+// logTo logs the record to the logger as the engine logged it.
+func (rec GameLogRecord) logTo(logger eventlog.Logger) {
+	if rec.Round != 0 {
+		logger = logger.Sub().SetKey("round", rec.Round)
+	}
+	logPayload(logger.Event().With(rec.State), rec.Payload)
+}
+
+// GameLogDecoder reads typed events from a JSON game log.
+type GameLogDecoder struct {
+	r *eventlog.Reader
+}
+
+func NewGameLogDecoder(r io.Reader) *GameLogDecoder {
+	return &GameLogDecoder{r: eventlog.NewReader(r)}
+}
+
+// Next returns the next event of the log, or io.EOF after the last one.
+func (d *GameLogDecoder) Next() (GameLogRecord, error) {
+	e, err := d.r.Next()
+	if err != nil {
+		return GameLogRecord{}, err
+	}
+	rec, err := DecodeGameLogRecord(e)
+	if err != nil {
+		return GameLogRecord{}, fmt.Errorf("line %d: %w", d.r.Line(), err)
+	}
+	return rec, nil
+}
+
+// Line returns the line number of the event last returned by Next, starting at 1.
+func (d *GameLogDecoder) Line() int {
+	return d.r.Line()
+}
@@ -0,0 +1,171 @@
+// This file generates the JSON Schema of game logs from the payload types
+
+package engine
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/WillMorrison/JouleQuestCardGame/core"
+	"github.com/WillMorrison/JouleQuestCardGame/eventlog"
+)
+
+//go:generate go run ../cmd/gamelog_schema -out gamelog.schema.json
+
+type jsonSchema = map[string]any
+
+// schemaGen collects the definitions of the struct types used in a schema.
+type schemaGen struct {
+	defs jsonSchema
+}
+
+// loggableSchema returns the schema of a Loggable enum logged by name.
+func loggableSchema(t reflect.Type) jsonSchema {
+	return jsonSchema{"type": "string", "enum": eventlog.EnumNames(t)}
+}
+
+// fieldSchema returns the schema of a payload field, which is logged by name if it is Loggable, or as JSON.
+func (g *schemaGen) fieldSchema(t reflect.Type) jsonSchema {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if eventlog.IsLoggableField(t) {
+		return loggableSchema(t)
+	}
+	return g.jsonSchema(t)
+}
+
+var textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+
+// jsonSchema returns the schema of the JSON encoding of values of type t.
+func (g *schemaGen) jsonSchema(t reflect.Type) jsonSchema {
+	if t == reflect.TypeFor[PlayerState]() {
+		// PlayerState has its own JSON encoding
+		g.define(t, func() jsonSchema {
+			return jsonSchema{
+				"type": "object",
+				"properties": jsonSchema{
+					"Status": loggableSchema(reflect.TypeFor[core.PlayerStatus]()),
+					"Reason": loggableSchema(reflect.TypeFor[core.LossCondition]()),
+					"Money":  jsonSchema{"type": "integer"},
+					"Assets": g.jsonSchema(reflect.TypeOf(PlayerState{}.Assets)),
+				},
+				"required":             []string{"Status", "Money", "Assets"},
+				"additionalProperties": false,
+			}
+		})
+		return jsonSchema{"$ref": "#/$defs/" + t.Name()}
+	}
+	if t.Kind() == reflect.Int && t.Implements(textMarshalerType) {
+		return jsonSchema{"type": "string", "enum": eventlog.EnumNames(t)}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return jsonSchema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return jsonSchema{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return jsonSchema{"type": "integer", "minimum": 0}
+	case reflect.String:
+		return jsonSchema{"type": "string"}
+	case reflect.Slice:
+		return jsonSchema{"type": "array", "items": g.jsonSchema(t.Elem())}
+	case reflect.Array:
+		return jsonSchema{"type": "array", "items": g.jsonSchema(t.Elem()), "minItems": t.Len(), "maxItems": t.Len()}
+	case reflect.Map:
+		s := jsonSchema{"type": "object", "additionalProperties": g.jsonSchema(t.Elem())}
+		if t.Key().Kind() == reflect.Int {
+			s["propertyNames"] = jsonSchema{"pattern": "^-?[0-9]+$"}
+		}
+		return s
+	case reflect.Pointer:
+		return g.jsonSchema(t.Elem())
+	case reflect.Struct:
+		g.define(t, func() jsonSchema { return g.structSchema(t) })
+		return jsonSchema{"$ref": "#/$defs/" + t.Name()}
+	}
+	panic("no JSON schema for type " + t.String())
+}
+
+// define adds the definition of a struct type, unless it is defined already.
+func (g *schemaGen) define(t reflect.Type, schema func() jsonSchema) {
+	if _, ok := g.defs[t.Name()]; ok {
+		return
+	}
+	g.defs[t.Name()] = nil // Placeholder for recursive types
+	g.defs[t.Name()] = schema()
+}
+
+// structSchema returns the schema of a struct encoded by encoding/json.
+func (g *schemaGen) structSchema(t reflect.Type) jsonSchema {
+	props := jsonSchema{}
+	required := []string{}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		props[name] = g.jsonSchema(f.Type)
+		if opts != "omitempty" {
+			required = append(required, name)
+		}
+	}
+	return jsonSchema{"type": "object", "properties": props, "required": required, "additionalProperties": false}
+}
+
+// payloadSchema returns the schema of the events with the payload p.
+func (g *schemaGen) payloadSchema(p GameLogPayload) jsonSchema {
+	t := reflect.TypeOf(p)
+	props := jsonSchema{"game_event": jsonSchema{"const": p.GameLogEvent().String()}}
+	required := []string{}
+	if states := payloadStates(p); states != nil {
+		var names []string
+		for _, s := range states {
+			names = append(names, s.String())
+		}
+		props["state"] = jsonSchema{"enum": names}
+	}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		key, optional := eventlog.FieldKey(f)
+		props[key] = g.fieldSchema(f.Type)
+		if !optional {
+			required = append(required, key)
+		}
+	}
+	return jsonSchema{"title": t.Name(), "properties": props, "required": required}
+}
+
+// GameLogSchema returns a JSON Schema of the events of JSON game logs, one of which is logged per line.
+func GameLogSchema() ([]byte, error) {
+	g := schemaGen{defs: jsonSchema{}}
+	var payloads []any
+	for _, p := range gameLogPayloads {
+		payloads = append(payloads, g.payloadSchema(p))
+	}
+	schema := jsonSchema{
+		"$schema":     "https://json-schema.org/draft/2020-12/schema",
+		"title":       "JouleQuest game log event",
+		"description": "One event of a JSON game log, as decoded by engine.DecodeGameLogRecord. Logs have one event per line.",
+		"type":        "object",
+		"properties": jsonSchema{
+			"game_event": loggableSchema(reflect.TypeFor[GameLogEvent]()),
+			"state":      loggableSchema(reflect.TypeFor[StateMachineState]()),
+			"round":      jsonSchema{"type": "integer", "minimum": 1, "description": "Not set in the GameStart event, which comes before the first round."},
+		},
+		"required":              []string{"game_event", "state"},
+		"oneOf":                 payloads,
+		"unevaluatedProperties": false,
+		"$defs":                 g.defs,
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
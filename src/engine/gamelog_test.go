@@ -0,0 +1,228 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/WillMorrison/JouleQuestCardGame/assets"
+	"github.com/WillMorrison/JouleQuestCardGame/core"
+	"github.com/WillMorrison/JouleQuestCardGame/eventlog"
+	"github.com/WillMorrison/JouleQuestCardGame/params"
+)
+
+// samplePayloads returns a payload of every type, with the state it is logged in.
+func samplePayloads() []GameLogRecord {
+	money := -3
+	stability := core.GridStabilityBad
+	risk := core.EventRiskHigh
+	action := PlayerAction{Type: ActionTypeBuildAsset, PlayerIndex: 1, AssetType: assets.TypeBattery, Cost: 2}
+	lost := PlayerState{Status: core.PlayerStatusLost, Reason: core.LossConditionPlayerBankrupt, Money: -1, Assets: assets.AssetMix{Renewables: 2}}
+	return []GameLogRecord{
+		{State: StateMachineStateGameStart, Payload: GameStartEvent{Params: params.Default, NumPlayers: 3, RNGSeed: 1 << 63}},
+		{State: StateMachineStateBuildPhase, Round: 1, Payload: PhaseStartEvent{}},
+		{State: StateMachineStateGameEnd, Round: 4, Payload: GameEndEvent{Status: core.GameStatusLoss, Reason: core.LossConditionGridUnstable, TotalEmissions: 9, Players: []PlayerState{lost, {Money: 4}}}},
+		{State: StateMachineStateBuildPhase, Round: 1, Payload: PlayerActionEvent{Action: action}},
+		{State: StateMachineStateBuildPhase, Round: 1, Payload: PlayerActionInvalidEvent{Action: action, Error: "no"}},
+		{State: StateMachineStateBuildPhase, Round: 1, Payload: PlayerActionUndoneEvent{Action: action}},
+		{State: StateMachineStateOperatePhase, Round: 2, Payload: EventDrawnEvent{Risk: risk}},
+		{State: StateMachineStateOperatePhase, Round: 2, Payload: GridOutcomeEvent{GridOutcome: Snapshot{AssetMix: assets.AssetMix{FossilsWholesale: 1}, GridStability: stability}, NewEmissions: 1}},
+		{State: StateMachineStateOperatePhase, Round: 2, Payload: MarketOutcomeEvent{PlayerIndex: 1, AssetMix: assets.AssetMix{Renewables: 1}, PnL: -4, Money: money}},
+		{State: StateMachineStateOperatePhase, Round: 2, Payload: CarbonTaxAppliedEvent{}},
+		{State: StateMachineStateOperatePhase, Round: 2, Payload: PlayerLosesEvent{PlayerIndex: 1, Reason: core.LossConditionPlayerBankrupt, Money: &money}},
+		{State: StateMachineStateOperatePhase, Round: 2, Payload: EveryoneLosesEvent{Reason: core.LossConditionGridUnstable, GridStability: &stability, Risk: &risk}},
+		{State: StateMachineStateBuildPhase, Round: 2, Payload: EveryoneLosesEvent{Reason: core.LossConditionUnownedTakeoverAssets, TakeoverPool: &assets.AssetMix{}, PlayerFunds: []int{0, 1}}},
+		{State: StateMachineStateOperatePhase, Round: 3, Payload: GlobalWinEvent{}},
+	}
+}
+
+// logRecord logs a record like the engine does, and returns the logged line.
+func logRecord(rec GameLogRecord) []byte {
+	var buf bytes.Buffer
+	rec.logTo(eventlog.NewJsonLogger(&buf))
+	return buf.Bytes()
+}
+
+func Test_DecodeGameLogRecord_RoundTrip(t *testing.T) {
+	for _, want := range samplePayloads() {
+		t.Run(reflect.TypeOf(want.Payload).Name(), func(t *testing.T) {
+			line := logRecord(want)
+			e, err := eventlog.DecodeEvent(line)
+			if err != nil {
+				t.Fatalf("DecodeEvent() error = %v", err)
+			}
+
+			got, err := DecodeGameLogRecord(e)
+
+			if err != nil {
+				t.Fatalf("DecodeGameLogRecord(%s) error = %v", line, err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Got %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func Test_GameLogDecoder_PlayedGame(t *testing.T) {
+	_, lines := playLoggedGame(t)
+	d := NewGameLogDecoder(strings.NewReader(strings.Join(lines, "")))
+
+	for i, line := range lines {
+		rec, err := d.Next()
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		// Logging the decoded record again gives the same event
+		want, _ := eventlog.DecodeEvent([]byte(line))
+		got, _ := eventlog.DecodeEvent(logRecord(rec))
+		if !got.Equal(want) {
+			t.Errorf("Line %d decoded to %+v, which logs as\n%s\nwant\n%s", i+1, rec, logRecord(rec), line)
+		}
+	}
+}
+
+func Test_DecodeGameLogRecord_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		event string
+	}{
+		{name: "unknown event", event: `{"game_event":"Nope","state":"StateMachineStateBuildPhase"}`},
+		{name: "no state", event: `{"game_event":"GlobalWin"}`},
+		{name: "missing key", event: `{"game_event":"PlayerAction","state":"StateMachineStateBuildPhase"}`},
+		{name: "invalid enum", event: `{"game_event":"EventDrawn","state":"StateMachineStateOperatePhase","event_risk":"Huge"}`},
+		{name: "transition in wrong state", event: `{"game_event":"StateMachineTransition","state":"Nope"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := eventlog.DecodeEvent([]byte(tt.event))
+			if err != nil {
+				t.Fatalf("DecodeEvent() error = %v", err)
+			}
+			if got, err := DecodeGameLogRecord(e); err == nil {
+				t.Errorf("DecodeGameLogRecord() = %+v, want error", got)
+			}
+		})
+	}
+}
+
+func Test_GameLogSchema_UpToDate(t *testing.T) {
+	want, err := GameLogSchema()
+	if err != nil {
+		t.Fatalf("GameLogSchema() error = %v", err)
+	}
+	got, err := os.ReadFile("gamelog.schema.json")
+	if err != nil {
+		t.Fatalf("Cannot read schema: %s", err)
+	}
+	if string(got) != string(want)+"\n" {
+		t.Errorf("gamelog.schema.json is out of date, run go generate ./engine")
+	}
+}
+
+func Test_GameLogSchema_DescribesPayloads(t *testing.T) {
+	data, err := GameLogSchema()
+	if err != nil {
+		t.Fatalf("GameLogSchema() error = %v", err)
+	}
+	var schema struct {
+		Properties map[string]any
+		OneOf      []struct {
+			Title      string
+			Properties map[string]any
+			Required   []string
+		}
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("Cannot decode schema: %s", err)
+	}
+
+	for _, rec := range samplePayloads() {
+		name := reflect.TypeOf(rec.Payload).Name()
+		e, _ := eventlog.DecodeEvent(logRecord(rec))
+		var matched int
+		for _, s := range schema.OneOf {
+			if s.Title != name {
+				continue
+			}
+			matched++
+			for key := range e {
+				if s.Properties[key] == nil && schema.Properties[key] == nil {
+					t.Errorf("%s event has key %q, which its schema lacks", name, key)
+				}
+			}
+			for _, key := range s.Required {
+				if _, ok := e[key]; !ok {
+					t.Errorf("%s event lacks required key %q", name, key)
+				}
+			}
+		}
+		if matched != 1 {
+			t.Errorf("Schema has %d entries for %s, want 1", matched, name)
+		}
+	}
+}
+
+func Test_GameLog_SlogLevels(t *testing.T) {
+	var buf bytes.Buffer
+	logger := eventlog.NewSlogLogger(slog.NewJSONHandler(&buf, nil), GameLogEventStateMachineTransition.LogKey())
+	pgs, err := NewSeededProceduralGame(2, params.Default, 42, logger)
+	if err != nil {
+		t.Fatalf("Couldn't create new game: %s", err)
+	}
+
+	pgs.ApplyPlayerAction(PlayerAction{Type: ActionTypeBuildAsset, PlayerIndex: 0, AssetType: assets.TypeRenewable, Cost: 1})
+	for pgs.s != StateMachineStateGameEnd {
+		pgs.ApplyPlayerAction(pgs.PossibleActions()[0])
+	}
+
+	var got []string
+	for line := range strings.Lines(buf.String()) {
+		var record struct{ Level, Msg string }
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("Cannot decode record %s: %s", line, err)
+		}
+		got = append(got, record.Level+" "+record.Msg)
+	}
+	if got[0] != "INFO StateMachineTransition" || got[1] != "WARN PlayerActionInvalid" || got[len(got)-1] != "INFO StateMachineTransition" {
+		t.Errorf("Got records %q, want the GameStart transition, the invalid action, ..., and the GameEnd transition", got)
+	}
+	for _, r := range got[2 : len(got)-1] {
+		if r != "INFO EveryoneLoses" && r != "INFO PlayerLoses" && r != "INFO GlobalWin" {
+			t.Errorf("Got record %q between the invalid action and the end of the game, want only wins and losses", r)
+		}
+	}
+}
+
+func Test_KeepTerminalEvents(t *testing.T) {
+	var buf bytes.Buffer
+	pgs, err := NewSeededProceduralGame(2, params.Default, 42, eventlog.NewFilterLogger(eventlog.NewJsonLogger(&buf), KeepTerminalEvents))
+	if err != nil {
+		t.Fatalf("Couldn't create new game: %s", err)
+	}
+	for pgs.s != StateMachineStateGameEnd {
+		pgs.ApplyPlayerAction(pgs.PossibleActions()[0])
+	}
+
+	d := NewGameLogDecoder(&buf)
+	var got []string
+	for {
+		rec, err := d.Next()
+		if err != nil {
+			break
+		}
+		got = append(got, reflect.TypeOf(rec.Payload).Name())
+	}
+	if len(got) < 2 || got[len(got)-1] != "GameEndEvent" {
+		t.Fatalf("Got events %q, want a win or loss and GameEndEvent", got)
+	}
+	for _, name := range got[:len(got)-1] {
+		if name != "PlayerLosesEvent" && name != "EveryoneLosesEvent" && name != "GlobalWinEvent" {
+			t.Errorf("Got %s event, want only wins and losses before GameEndEvent", name)
+		}
+	}
+}
@@ -18,11 +18,12 @@ func _() {
 	_ = x[GameLogEventPlayerLoses-7]
 	_ = x[GameLogEventEveryoneLoses-8]
 	_ = x[GameLogEventGlobalWin-9]
+	_ = x[GameLogEventPlayerActionUndone-10]
 }
 
-const _GameLogEvent_name = "StateMachineTransitionPlayerActionPlayerActionInvalidEventDrawnGridOutcomeMarketOutcomeCarbonTaxAppliedPlayerLosesEveryoneLosesGlobalWin"
+const _GameLogEvent_name = "StateMachineTransitionPlayerActionPlayerActionInvalidEventDrawnGridOutcomeMarketOutcomeCarbonTaxAppliedPlayerLosesEveryoneLosesGlobalWinPlayerActionUndone"
 
-var _GameLogEvent_index = [...]uint8{0, 22, 34, 53, 63, 74, 87, 103, 114, 127, 136}
+var _GameLogEvent_index = [...]uint8{0, 22, 34, 53, 63, 74, 87, 103, 114, 127, 136, 154}
 
 func (i GameLogEvent) String() string {
 	idx := int(i) - 0
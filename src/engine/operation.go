@@ -79,51 +79,49 @@ func (gs GameState) winConditionMet() bool {
 // OperatePhase handles calculations
 func OperatePhase(gs *GameState) StateRunner {
 	logger := gs.Logger.Sub().Set(StateMachineStateOperatePhase)
-	logger.Event().With(GameLogEventStateMachineTransition).Log()
+	logPayload(logger.Event(), PhaseStartEvent{})
 
 	// Draw random event
 	risk := core.EventRisk(gs.pcg.Uint64() % 3)
-	logger.Event().With(GameLogEventEventDrawn, risk).Log()
+	logPayload(logger.Event(), EventDrawnEvent{Risk: risk})
 
 	// Calculate asset mix, price volatility, grid stability, and new emissions
 	gridOutcome := gs.getSnapshot()
-	logger.Event().
-		WithKey("grid_outcome", gridOutcome).
-		WithKey("new_emissions", gridOutcome.AssetMix.Emissions()).
-		With(GameLogEventGridOutcome).Log()
+	newEmissions := gridOutcome.AssetMix.Emissions()
+	logPayload(logger.Event(), GridOutcomeEvent{GridOutcome: gridOutcome, NewEmissions: newEmissions})
 
 	// Check global loss conditions
 	if !gs.generationConstraintMet(gridOutcome.AssetMix) {
 		gs.SetGlobalLossWithReason(core.LossConditionInsufficientGeneration)
-		logger.Event().With(GameLogEventEveryoneLoses, gs.Reason).WithKey("generation_assets", gridOutcome.AssetMix.GenerationAssets()).Log()
+		generationAssets := gridOutcome.AssetMix.GenerationAssets()
+		logPayload(logger.Event(), EveryoneLosesEvent{Reason: gs.Reason, GenerationAssets: &generationAssets})
 		return GameEnd
 	}
 	if int(gridOutcome.GridStability) < int(risk) {
 		gs.SetGlobalLossWithReason(core.LossConditionGridUnstable)
-		logger.Event().With(GameLogEventEveryoneLoses, gs.Reason, gridOutcome.GridStability, risk).Log()
+		logPayload(logger.Event(), EveryoneLosesEvent{Reason: gs.Reason, GridStability: &gridOutcome.GridStability, Risk: &risk})
 		return GameEnd
 	}
-	gs.CarbonEmissions += gridOutcome.AssetMix.Emissions()
+	gs.CarbonEmissions += newEmissions
 	if gs.CarbonEmissions > gs.Params.EmissionsCap {
 		gs.SetGlobalLossWithReason(core.LossConditionCarbonEmissionsExceeded)
-		logger.Event().With(GameLogEventEveryoneLoses, gs.Reason).WithKey("total_emissions", gs.CarbonEmissions).WithKey("new_emissions", gridOutcome.AssetMix.Emissions()).Log()
+		logPayload(logger.Event(), EveryoneLosesEvent{Reason: gs.Reason, TotalEmissions: &gs.CarbonEmissions, NewEmissions: &newEmissions})
 		return GameEnd
 	}
 
 	// Do market PnL calculations for each player
 	var numActivePlayers int
 	for pi, p := range gs.activePlayers() {
-		pLogger := logger.Sub().SetKey("player_index", pi)
 		numActivePlayers++
 		playerPnL := gs.playerPnL(pi, gridOutcome)
 		p.Money += playerPnL
-		pLogger.Event().WithKey("player_asset_mix", p.Assets).WithKey("player_PnL", playerPnL).WithKey("player_money", p.Money).With(GameLogEventMarketOutcome).Log()
+		logPayload(logger.Event(), MarketOutcomeEvent{PlayerIndex: pi, AssetMix: p.Assets, PnL: playerPnL, Money: p.Money})
 
 		// Check player loss conditions
 		if p.Money < 0 {
 			p.SetLossWithReason(core.LossConditionPlayerBankrupt)
 			gs.movePlayerAssetsToTakeoverPool(pi)
-			pLogger.Event().With(GameLogEventPlayerLoses, p.Reason).WithKey("player_money", p.Money).Log()
+			logPayload(logger.Event(), PlayerLosesEvent{PlayerIndex: pi, Reason: p.Reason, Money: &p.Money})
 			numActivePlayers--
 		}
 	}
@@ -133,7 +131,7 @@ func OperatePhase(gs *GameState) StateRunner {
 	// If all players are out (e.g. due to bankruptcy), the game is a loss
 	if numActivePlayers == 0 {
 		gs.SetGlobalLossWithReason(core.LossConditionNoActivePlayers)
-		logger.Event().With(GameLogEventEveryoneLoses, core.LossConditionNoActivePlayers).Log()
+		logPayload(logger.Event(), EveryoneLosesEvent{Reason: core.LossConditionNoActivePlayers})
 		return GameEnd
 	}
 
@@ -147,13 +145,13 @@ func OperatePhase(gs *GameState) StateRunner {
 		lastFossilPlayerIndex := slices.IndexFunc(gs.Players, PlayerState.HasFossilAssets)
 		if lastFossilPlayerIndex != -1 {
 			gs.Players[lastFossilPlayerIndex].SetLossWithReason(core.LossConditionLastPlayerWithFossilAssets)
-			logger.Event().WithKey("player_index", lastFossilPlayerIndex).With(GameLogEventPlayerLoses, core.LossConditionLastPlayerWithFossilAssets).Log()
+			logPayload(logger.Event(), PlayerLosesEvent{PlayerIndex: lastFossilPlayerIndex, Reason: core.LossConditionLastPlayerWithFossilAssets})
 
 			// Check if we just eliminated the last player. If so, the game is a loss.
 			numActivePlayers--
 			if numActivePlayers == 0 {
 				gs.SetGlobalLossWithReason(core.LossConditionNoActivePlayers)
-				logger.Event().With(GameLogEventEveryoneLoses, core.LossConditionNoActivePlayers).Log()
+				logPayload(logger.Event(), EveryoneLosesEvent{Reason: core.LossConditionNoActivePlayers})
 				return GameEnd
 			}
 		}
@@ -162,7 +160,7 @@ func OperatePhase(gs *GameState) StateRunner {
 
 	// There are active players left, they win!
 	gs.Status = core.GameStatusWin
-	logger.Event().With(GameLogEventGlobalWin).Log()
+	logPayload(logger.Event(), GlobalWinEvent{})
 	return GameEnd
 }
 
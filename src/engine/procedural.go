@@ -3,6 +3,7 @@ package engine
 import (
 	"errors"
 
+	"github.com/WillMorrison/JouleQuestCardGame/assets"
 	"github.com/WillMorrison/JouleQuestCardGame/core"
 	"github.com/WillMorrison/JouleQuestCardGame/eventlog"
 	"github.com/WillMorrison/JouleQuestCardGame/params"
@@ -11,19 +12,37 @@ import (
 type ProceduralGameState struct {
 	s  StateMachineState
 	gs GameState
+
+	history []undoEntry // Up to core.MaxUndo actions applied in the current build phase, most recent last
+	prev    GameState   // State before the last applied action, for rewards
+}
+
+// undoEntry records the state changed by an applied player action, so that it can be undone.
+type undoEntry struct {
+	action       PlayerAction
+	player       PlayerState // The acting player's state before the action
+	takeoverPool assets.AssetMix
 }
 
 func NewProceduralGame(numPlayers int, gameParams params.Params, logger eventlog.Logger) (*ProceduralGameState, error) {
+	return NewSeededProceduralGame(numPlayers, gameParams, 0, logger)
+}
+
+// NewSeededProceduralGame is like NewProceduralGame, but seeds the RNG before the game starts so that the seed is
+// included in the GameStart log event.
+func NewSeededProceduralGame(numPlayers int, gameParams params.Params, seed uint64, logger eventlog.Logger) (*ProceduralGameState, error) {
 	gs, err := NewGame(numPlayers, gameParams, logger, nil, nil)
 	if err != nil {
 		return nil, err
 	}
+	gs.SetRNGSeed(seed)
 	GameStart(gs)
 	pgs := &ProceduralGameState{
 		s:  StateMachineStateGameStart,
 		gs: *gs,
 	}
 	pgs.startBuildPhase()
+	pgs.prev = pgs.gs.copyForReward()
 	return pgs, nil
 }
 
@@ -61,12 +80,14 @@ func (pgs ProceduralGameState) haveBuildingPlayers() bool {
 }
 
 var ErrCannotApplyActionsOutsideBuildPhase = errors.New("cannot apply player actions outside the build phase")
+var ErrNothingToUndo = errors.New("no player actions to undo in this build phase")
 
 func (pgs *ProceduralGameState) startBuildPhase() {
 	pgs.s = StateMachineStateBuildPhase
 	pgs.gs.Round++
 	pgs.gs.Logger = pgs.gs.Logger.SetKey("round", pgs.gs.Round)
-	pgs.logEvent().With(GameLogEventStateMachineTransition).Log()
+	logPayload(pgs.logEvent(), PhaseStartEvent{})
+	pgs.history = nil
 
 	for _, p := range pgs.gs.activePlayers() {
 		p.isBuilding = true
@@ -94,18 +115,30 @@ func (pgs *ProceduralGameState) runUntilBuildPhase() {
 	}
 }
 
-func (pgs *ProceduralGameState) ApplyPlayerAction(chosenAction PlayerAction) {
+// ApplyPlayerAction applies the action and advances the game until input is needed again. If the action cannot be
+// applied it is logged as invalid, the state is unchanged, and the error is returned.
+func (pgs *ProceduralGameState) ApplyPlayerAction(chosenAction PlayerAction) error {
 	if pgs.s != StateMachineStateBuildPhase {
-		return
+		return ErrCannotApplyActionsOutsideBuildPhase
 	}
 
 	// Try to apply the player action to the underlying game state
+	prev := pgs.gs.copyForReward()
+	undo := undoEntry{action: chosenAction, takeoverPool: pgs.gs.TakeoverPool}
+	if chosenAction.PlayerIndex >= 0 && chosenAction.PlayerIndex < len(pgs.gs.Players) {
+		undo.player = pgs.gs.Players[chosenAction.PlayerIndex]
+	}
 	err := pgs.gs.applyPlayerAction(chosenAction)
 	if err != nil {
-		pgs.logEvent().With(GameLogEventPlayerActionInvalid).WithKey("invalid_action", chosenAction).WithKey("error", err.Error()).Log()
-		return
+		logPayload(pgs.logEvent(), PlayerActionInvalidEvent{Action: chosenAction, Error: err.Error()})
+		return err
+	}
+	if len(pgs.history) == core.MaxUndo {
+		pgs.history = pgs.history[1:]
 	}
-	pgs.logEvent().With(GameLogEventPlayerAction).WithKey("action", chosenAction).Log()
+	pgs.history = append(pgs.history, undo)
+	pgs.prev = prev
+	logPayload(pgs.logEvent(), PlayerActionEvent{Action: chosenAction})
 
 	// Figure out where the game goes from here.
 	actions := pgs.gs.possibleActions()
@@ -125,13 +158,33 @@ func (pgs *ProceduralGameState) ApplyPlayerAction(chosenAction PlayerAction) {
 				for _, p := range pgs.gs.Players {
 					money = append(money, p.Money)
 				}
-				pgs.logEvent().With(GameLogEventEveryoneLoses, pgs.gs.Reason).WithKey("takeover_pool", pgs.gs.TakeoverPool).WithKey("player_funds", money).Log()
+				logPayload(pgs.logEvent(), EveryoneLosesEvent{Reason: pgs.gs.Reason, TakeoverPool: &pgs.gs.TakeoverPool, PlayerFunds: money})
 			} else {
 				pgs.gs.SetGlobalLossWithReason(core.LossConditionNoActivePlayers) // Should never happen, but if it does, force a game loss
-				pgs.logEvent().With(GameLogEventEveryoneLoses, pgs.gs.Reason).Log()
+				logPayload(pgs.logEvent(), EveryoneLosesEvent{Reason: pgs.gs.Reason})
 			}
 			pgs.s = StateMachineStateGameEnd
 		}
 	}
 	pgs.runUntilBuildPhase()
+	return nil
+}
+
+// Undo reverts the most recently applied player action, restoring the acting player's money and assets and the
+// takeover pool, and returns the action that was undone. Up to core.MaxUndo actions can be undone in a row. Only
+// actions from the current build phase can be undone; once all players have finished and the operate phase has run,
+// the history is cleared. Like the compact engine's CodeNothingToUndo, ErrNothingToUndo is returned whenever there is
+// no action to undo, including outside the build phase.
+func (pgs *ProceduralGameState) Undo() (PlayerAction, error) {
+	if pgs.s != StateMachineStateBuildPhase || len(pgs.history) == 0 {
+		return PlayerAction{}, ErrNothingToUndo
+	}
+	undo := pgs.history[len(pgs.history)-1]
+	pgs.history = pgs.history[:len(pgs.history)-1]
+
+	pgs.gs.Players[undo.action.PlayerIndex] = undo.player
+	pgs.gs.TakeoverPool = undo.takeoverPool
+	pgs.prev = pgs.gs.copyForReward() // An undone action has no reward
+	logPayload(pgs.logEvent(), PlayerActionUndoneEvent{Action: undo.action})
+	return undo.action, nil
 }
@@ -0,0 +1,139 @@
+package engine
+
+import (
+	"bytes"
+	"errors"
+	randv2 "math/rand/v2"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/WillMorrison/JouleQuestCardGame/assets"
+	"github.com/WillMorrison/JouleQuestCardGame/core"
+	"github.com/WillMorrison/JouleQuestCardGame/eventlog"
+	"github.com/WillMorrison/JouleQuestCardGame/params"
+)
+
+func Test_ProceduralGameState_Undo_RestoresState(t *testing.T) {
+	// Arrange
+	var logBuf bytes.Buffer
+	pgs, err := NewProceduralGame(2, params.Default, eventlog.NewJsonLogger(&logBuf))
+	if err != nil {
+		t.Fatalf("Couldn't create new game: %s", err)
+	}
+	before := pgs.Game()
+	build := PlayerAction{Type: ActionTypeBuildAsset, PlayerIndex: 0, AssetType: assets.TypeRenewable, Cost: params.Default.BuildCost(assets.TypeRenewable)}
+	scrap := PlayerAction{Type: ActionTypeScrapAsset, PlayerIndex: 1, AssetType: assets.TypeFossil, Cost: params.Default.ScrapCost(assets.TypeFossil)}
+	pgs.ApplyPlayerAction(build)
+	pgs.ApplyPlayerAction(scrap)
+
+	// Act
+	undone1, err1 := pgs.Undo()
+	undone2, err2 := pgs.Undo()
+	_, err3 := pgs.Undo()
+
+	// Assert
+	if err1 != nil || undone1 != scrap {
+		t.Errorf("first Undo() = %+v, %v, want %+v, nil", undone1, err1, scrap)
+	}
+	if err2 != nil || undone2 != build {
+		t.Errorf("second Undo() = %+v, %v, want %+v, nil", undone2, err2, build)
+	}
+	if !errors.Is(err3, ErrNothingToUndo) {
+		t.Errorf("third Undo() error = %v, want %v", err3, ErrNothingToUndo)
+	}
+	if after := pgs.Game(); !reflect.DeepEqual(before, after) {
+		t.Errorf("Undo did not restore state:\ngot  %+v\nwant %+v", after, before)
+	}
+	if n := strings.Count(logBuf.String(), GameLogEventPlayerActionUndone.String()); n != 2 {
+		t.Errorf("Got %d undo events in log, want 2:\n%s", n, logBuf.String())
+	}
+}
+
+func Test_ProceduralGameState_Undo_Finished(t *testing.T) {
+	pgs, err := NewProceduralGame(2, params.Default, eventlog.NullLogger{})
+	if err != nil {
+		t.Fatalf("Couldn't create new game: %s", err)
+	}
+	pgs.ApplyPlayerAction(PlayerAction{Type: ActionTypeFinished, PlayerIndex: 0})
+
+	if _, err := pgs.Undo(); err != nil {
+		t.Fatalf("Undo() error = %v", err)
+	}
+
+	if !pgs.gs.Players[0].isBuilding {
+		t.Errorf("Player 0 should be building again after undoing finished")
+	}
+}
+
+func Test_ProceduralGameState_Undo_DoesNotCrossOperatePhase(t *testing.T) {
+	pgs, err := NewProceduralGame(2, params.Default, eventlog.NullLogger{})
+	if err != nil {
+		t.Fatalf("Couldn't create new game: %s", err)
+	}
+	pgs.ApplyPlayerAction(PlayerAction{Type: ActionTypeFinished, PlayerIndex: 0})
+	pgs.ApplyPlayerAction(PlayerAction{Type: ActionTypeFinished, PlayerIndex: 1})
+	if pgs.gs.Round != 2 {
+		t.Fatalf("Expected operate phase to have run, round is %d", pgs.gs.Round)
+	}
+	before := pgs.Game()
+
+	_, err = pgs.Undo()
+
+	if !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("Undo() error = %v, want %v", err, ErrNothingToUndo)
+	}
+	if after := pgs.Game(); !reflect.DeepEqual(before, after) {
+		t.Errorf("Failed Undo changed state:\ngot  %+v\nwant %+v", after, before)
+	}
+}
+
+func Test_ProceduralGameState_Undo_AfterGameEnd(t *testing.T) {
+	pgs, err := NewProceduralGame(2, params.Default, eventlog.NullLogger{})
+	if err != nil {
+		t.Fatalf("Couldn't create new game: %s", err)
+	}
+	playRandomActions(pgs, randv2.New(randv2.NewPCG(1, 2)), 1000)
+	if pgs.Game().Status == core.GameStatusOngoing {
+		t.Fatalf("Game did not end")
+	}
+
+	_, err = pgs.Undo()
+
+	if !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("Undo() error = %v, want %v", err, ErrNothingToUndo)
+	}
+}
+
+func Test_ProceduralGameState_ApplyPlayerAction_Invalid(t *testing.T) {
+	pgs, err := NewProceduralGame(2, params.Default, eventlog.NullLogger{})
+	if err != nil {
+		t.Fatalf("Couldn't create new game: %s", err)
+	}
+	before := pgs.Game()
+
+	err = pgs.ApplyPlayerAction(PlayerAction{Type: ActionTypeBuildAsset, PlayerIndex: 0, AssetType: assets.TypeRenewable, Cost: 1})
+
+	if err == nil {
+		t.Errorf("ApplyPlayerAction() with wrong cost succeeded, want error")
+	}
+	if after := pgs.Game(); !reflect.DeepEqual(before, after) {
+		t.Errorf("Invalid action changed state:\ngot  %+v\nwant %+v", after, before)
+	}
+}
+
+func Test_NewSeededProceduralGame_LogsSeed(t *testing.T) {
+	var logBuf bytes.Buffer
+
+	pgs, err := NewSeededProceduralGame(2, params.Default, 1234, eventlog.NewJsonLogger(&logBuf))
+
+	if err != nil {
+		t.Fatalf("Couldn't create new game: %s", err)
+	}
+	if pgs.gs.rngSeed != 1234 {
+		t.Errorf("rngSeed = %d, want 1234", pgs.gs.rngSeed)
+	}
+	if !strings.Contains(logBuf.String(), `"rng_seed":1234`) {
+		t.Errorf("GameStart event does not include the seed:\n%s", logBuf.String())
+	}
+}
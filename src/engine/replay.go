@@ -0,0 +1,152 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/WillMorrison/JouleQuestCardGame/eventlog"
+)
+
+// replayCheckedEvents are the events which a replay must reproduce exactly. They follow from the seed, parameters and
+// player actions, so any difference means the log doesn't describe a game this engine would play.
+var replayCheckedEvents = []GameLogEvent{
+	GameLogEventGridOutcome,
+	GameLogEventMarketOutcome,
+	GameLogEventPlayerLoses,
+	GameLogEventEveryoneLoses,
+}
+
+func isReplayChecked(e eventlog.Event) bool {
+	for _, gle := range replayCheckedEvents {
+		if e.Has(gle) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReplayDivergence is the error returned by Replay for the first logged event which the replayed game did not
+// reproduce.
+type ReplayDivergence struct {
+	Line     int            // Line of the logged event, or the line after the log if it ends early
+	Logged   eventlog.Event // Nil if the log ended before the replayed event
+	Replayed eventlog.Event // Nil if the replayed game didn't log a matching event
+	Reason   string
+}
+
+func (d *ReplayDivergence) Error() string {
+	msg := fmt.Sprintf("replay diverges at line %d: %s", d.Line, d.Reason)
+	if d.Logged != nil {
+		logged, _ := json.Marshal(d.Logged)
+		msg += fmt.Sprintf("; logged %s", logged)
+	}
+	if d.Replayed != nil {
+		replayed, _ := json.Marshal(d.Replayed)
+		msg += fmt.Sprintf("; replayed %s", replayed)
+	}
+	return msg
+}
+
+// replayLog collects the events logged by a replayed game.
+type replayLog struct {
+	events  []eventlog.Event
+	checked int // Number of events already compared to the log
+}
+
+func (l *replayLog) Write(p []byte) (int, error) {
+	e, err := eventlog.DecodeEvent(p)
+	if err != nil {
+		return 0, err
+	}
+	l.events = append(l.events, e)
+	return len(p), nil
+}
+
+// nextChecked returns the next replayed event which must match the log, if there is one.
+func (l *replayLog) nextChecked() (eventlog.Event, bool) {
+	for l.checked < len(l.events) {
+		e := l.events[l.checked]
+		l.checked++
+		if isReplayChecked(e) {
+			return e, true
+		}
+	}
+	return nil, false
+}
+
+// Replay rebuilds the game of a JSON event log. It starts a game with the parameters, number of players and RNG seed
+// of the GameStart event, which must be the first, then re-applies each logged action, invalid action and undo in
+// order. Every logged grid outcome, market outcome and loss must match the event the replayed game logs, or Replay
+// returns a *ReplayDivergence for the first that doesn't.
+//
+// The returned game is in the state reached at the end of the log.
+func Replay(r *eventlog.Reader) (*ProceduralGameState, error) {
+	start, err := r.Next()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read GameStart event: %w", err)
+	}
+	rec, err := DecodeGameLogRecord(start)
+	if err != nil {
+		return nil, fmt.Errorf("line %d: %w", r.Line(), err)
+	}
+	gameStart, ok := rec.Payload.(GameStartEvent)
+	if !ok {
+		return nil, fmt.Errorf("line %d: first event is not the GameStart transition", r.Line())
+	}
+
+	var replayed replayLog
+	pgs, err := NewSeededProceduralGame(gameStart.NumPlayers, gameStart.Params, gameStart.RNGSeed, eventlog.NewJsonLogger(&replayed))
+	if err != nil {
+		return nil, fmt.Errorf("line %d: cannot start game: %w", r.Line(), err)
+	}
+
+	for {
+		e, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		diverged := func(reason string, replayedEvent eventlog.Event) error {
+			return &ReplayDivergence{Line: r.Line(), Logged: e, Replayed: replayedEvent, Reason: reason}
+		}
+
+		if isReplayChecked(e) {
+			got, ok := replayed.nextChecked()
+			if !ok {
+				return nil, diverged("replayed game did not log this event", nil)
+			}
+			if !e.Equal(got) {
+				return nil, diverged(fmt.Sprintf("%s differs", e.Text(GameLogEventStateMachineTransition.LogKey())), got)
+			}
+			continue
+		}
+		rec, err := DecodeGameLogRecord(e)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", r.Line(), err)
+		}
+		switch p := rec.Payload.(type) {
+		case PlayerActionEvent:
+			if err := pgs.ApplyPlayerAction(p.Action); err != nil {
+				return nil, diverged(fmt.Sprintf("logged action was rejected: %s", err), nil)
+			}
+		case PlayerActionInvalidEvent:
+			if err := pgs.ApplyPlayerAction(p.Action); err == nil {
+				return nil, diverged("logged invalid action was applied", nil)
+			}
+		case PlayerActionUndoneEvent:
+			if _, err := pgs.Undo(); err != nil {
+				return nil, diverged(fmt.Sprintf("logged undo failed: %s", err), nil)
+			}
+		case GameStartEvent:
+			return nil, fmt.Errorf("line %d: log has more than one game", r.Line())
+		}
+	}
+	if got, ok := replayed.nextChecked(); ok {
+		return nil, &ReplayDivergence{Line: r.Line() + 1, Replayed: got, Reason: "log ended before the replayed event"}
+	}
+	return pgs, nil
+}
@@ -0,0 +1,108 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	randv2 "math/rand/v2"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/WillMorrison/JouleQuestCardGame/assets"
+	"github.com/WillMorrison/JouleQuestCardGame/eventlog"
+	"github.com/WillMorrison/JouleQuestCardGame/params"
+)
+
+// playLoggedGame plays a seeded game to its end with random actions, an invalid action and an undo, and returns the
+// game and its log lines.
+func playLoggedGame(t *testing.T) (*ProceduralGameState, []string) {
+	t.Helper()
+	var logBuf bytes.Buffer
+	pgs, err := NewSeededProceduralGame(3, params.Default, 42, eventlog.NewJsonLogger(&logBuf))
+	if err != nil {
+		t.Fatalf("Couldn't create new game: %s", err)
+	}
+	rng := randv2.New(randv2.NewPCG(1, 2))
+	playRandomActions(pgs, rng, 5)
+	pgs.ApplyPlayerAction(PlayerAction{Type: ActionTypeBuildAsset, PlayerIndex: 0, AssetType: assets.TypeRenewable, Cost: 1})
+	playRandomActions(pgs, rng, 1)
+	pgs.Undo()
+	playRandomActions(pgs, rng, 1000)
+	return pgs, strings.SplitAfter(strings.TrimSuffix(logBuf.String(), "\n"), "\n")
+}
+
+func Test_Replay_ReproducesGame(t *testing.T) {
+	orig, lines := playLoggedGame(t)
+
+	replayed, err := Replay(eventlog.NewReader(strings.NewReader(strings.Join(lines, ""))))
+
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if !reflect.DeepEqual(orig.Game(), replayed.Game()) || orig.s != replayed.s {
+		t.Errorf("Replayed game differs:\ngot  %+v\nwant %+v", replayed.Game(), orig.Game())
+	}
+}
+
+func Test_Replay_ReportsFirstDivergence(t *testing.T) {
+	_, lines := playLoggedGame(t)
+	firstLine := func(gle GameLogEvent) int {
+		for i, line := range lines {
+			if strings.Contains(line, `"game_event":"`+gle.String()+`"`) {
+				return i
+			}
+		}
+		t.Fatalf("Log has no %s event", gle)
+		return -1
+	}
+	tamper := func(i int, key string, value any) []string {
+		e, err := eventlog.DecodeEvent([]byte(lines[i]))
+		if err != nil {
+			t.Fatalf("Cannot decode line %d: %s", i+1, err)
+		}
+		e[key], _ = json.Marshal(value)
+		data, _ := json.Marshal(e)
+		tampered := append([]string(nil), lines...)
+		tampered[i] = string(data) + "\n"
+		return tampered
+	}
+	market := firstLine(GameLogEventMarketOutcome)
+	action := firstLine(GameLogEventPlayerAction)
+	grid := firstLine(GameLogEventGridOutcome)
+
+	tests := []struct {
+		name     string
+		lines    []string
+		wantLine int
+	}{
+		{name: "changed market outcome", lines: tamper(market, "player_money", -1000), wantLine: market + 1},
+		{name: "changed grid outcome", lines: tamper(grid, "new_emissions", 1000), wantLine: grid + 1},
+		{name: "changed action", lines: tamper(action, "action", PlayerAction{Type: ActionTypeBuildAsset, PlayerIndex: 0, AssetType: assets.TypeRenewable, Cost: 1}), wantLine: action + 1},
+		{name: "truncated", lines: lines[:grid], wantLine: grid + 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Replay(eventlog.NewReader(strings.NewReader(strings.Join(tt.lines, ""))))
+
+			var d *ReplayDivergence
+			if !errors.As(err, &d) {
+				t.Fatalf("Replay() error = %v, want a *ReplayDivergence", err)
+			}
+			if d.Line != tt.wantLine {
+				t.Errorf("Divergence at line %d, want line %d: %s", d.Line, tt.wantLine, d)
+			}
+		})
+	}
+}
+
+func Test_Replay_RequiresGameStart(t *testing.T) {
+	_, lines := playLoggedGame(t)
+
+	_, err := Replay(eventlog.NewReader(strings.NewReader(strings.Join(lines[1:], ""))))
+
+	var d *ReplayDivergence
+	if err == nil || errors.As(err, &d) {
+		t.Errorf("Replay() error = %v, want an error for the missing GameStart event", err)
+	}
+}
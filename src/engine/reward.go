@@ -0,0 +1,71 @@
+// This file contains per-player rewards for game transitions
+
+package engine
+
+import (
+	"slices"
+
+	"github.com/WillMorrison/JouleQuestCardGame/core"
+)
+
+// terminalReward returns +1 if the game was won in the transition and the player survived, -1 if the player lost
+// individually or with everyone else in the transition, and 0 otherwise.
+func terminalReward(before, after *GameState, pi int) int {
+	if before.Players[pi].Status != core.PlayerStatusActive {
+		return 0
+	}
+	switch {
+	case after.Status == core.GameStatusLoss && before.Status == core.GameStatusOngoing:
+		return -1
+	case after.Players[pi].Status == core.PlayerStatusLost:
+		return -1
+	case after.Status == core.GameStatusWin && before.Status == core.GameStatusOngoing:
+		return 1
+	}
+	return 0
+}
+
+// TransitionReward returns the reward for player pi for the transition from before to after, under the given scheme.
+// Returns 0 if pi is not a player in both states.
+func TransitionReward(before, after *GameState, pi int, scheme core.RewardScheme) int {
+	if pi < 0 || pi >= len(before.Players) || pi >= len(after.Players) {
+		return 0
+	}
+	switch scheme {
+	case core.RewardSchemeTerminal:
+		return terminalReward(before, after, pi)
+	case core.RewardSchemeMoneyDelta:
+		return after.Players[pi].Money - before.Players[pi].Money
+	case core.RewardSchemeShaped:
+		if before.Players[pi].Status != core.PlayerStatusActive {
+			return 0
+		}
+		penetration := after.LastSnapshot.AssetMix.RenewablePenetration() - before.LastSnapshot.AssetMix.RenewablePenetration()
+		emissions := after.CarbonEmissions - before.CarbonEmissions
+		return core.ShapedTerminalWeight*terminalReward(before, after, pi) + penetration - emissions
+	default:
+		return 0
+	}
+}
+
+// copyForReward returns a copy of the game state that is unaffected by further changes to gs, for computing rewards.
+func (gs *GameState) copyForReward() GameState {
+	c := *gs
+	c.Players = slices.Clone(gs.Players)
+	return c
+}
+
+// Reward returns the reward for player pi for the last applied player action, including any operate phase it
+// triggered, under the given scheme.
+func (pgs ProceduralGameState) Reward(pi int, scheme core.RewardScheme) int {
+	return TransitionReward(&pgs.prev, &pgs.gs, pi, scheme)
+}
+
+// Rewards returns the reward of every player for the last applied player action, under the given scheme.
+func (pgs ProceduralGameState) Rewards(scheme core.RewardScheme) []int {
+	rewards := make([]int, len(pgs.gs.Players))
+	for pi := range rewards {
+		rewards[pi] = pgs.Reward(pi, scheme)
+	}
+	return rewards
+}
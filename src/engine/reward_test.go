@@ -0,0 +1,48 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/WillMorrison/JouleQuestCardGame/assets"
+	"github.com/WillMorrison/JouleQuestCardGame/core"
+)
+
+func TestTransitionReward(t *testing.T) {
+	active := PlayerState{Status: core.PlayerStatusActive, Money: 10}
+	lost := PlayerState{Status: core.PlayerStatusLost, Money: 10}
+	ongoing := GameState{Status: core.GameStatusOngoing, Players: []PlayerState{active, active}}
+
+	tests := []struct {
+		name   string
+		before GameState
+		after  GameState
+		pi     int
+		scheme core.RewardScheme
+		want   int
+	}{
+		{"no change", ongoing, ongoing, 0, core.RewardSchemeTerminal, 0},
+		{"player loses", ongoing, GameState{Status: core.GameStatusOngoing, Players: []PlayerState{lost, active}}, 0, core.RewardSchemeTerminal, -1},
+		{"other player loses", ongoing, GameState{Status: core.GameStatusOngoing, Players: []PlayerState{lost, active}}, 1, core.RewardSchemeTerminal, 0},
+		{"game lost", ongoing, GameState{Status: core.GameStatusLoss, Players: []PlayerState{active, active}}, 1, core.RewardSchemeTerminal, -1},
+		{"game won", ongoing, GameState{Status: core.GameStatusWin, Players: []PlayerState{active, lost}}, 0, core.RewardSchemeTerminal, 1},
+		{"game won after losing", ongoing, GameState{Status: core.GameStatusWin, Players: []PlayerState{active, lost}}, 1, core.RewardSchemeTerminal, -1},
+		{"already lost", GameState{Players: []PlayerState{lost}}, GameState{Status: core.GameStatusLoss, Players: []PlayerState{lost}}, 0, core.RewardSchemeTerminal, 0},
+		{"money delta", ongoing, GameState{Players: []PlayerState{{Money: 3}, active}}, 0, core.RewardSchemeMoneyDelta, -7},
+		{"invalid player", ongoing, ongoing, 2, core.RewardSchemeMoneyDelta, 0},
+		{
+			"shaped",
+			ongoing,
+			GameState{Status: core.GameStatusWin, CarbonEmissions: 4, LastSnapshot: Snapshot{AssetMix: assets.AssetMix{Renewables: 1, FossilsWholesale: 1}}, Players: []PlayerState{active, active}},
+			0,
+			core.RewardSchemeShaped,
+			core.ShapedTerminalWeight + 50 - 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TransitionReward(&tt.before, &tt.after, tt.pi, tt.scheme); got != tt.want {
+				t.Errorf("TransitionReward() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
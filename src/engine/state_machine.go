@@ -2,7 +2,11 @@
 
 package engine
 
-import "github.com/WillMorrison/JouleQuestCardGame/core"
+import (
+	"log/slog"
+
+	"github.com/WillMorrison/JouleQuestCardGame/core"
+)
 
 /*
 State machine state diagram.
@@ -25,6 +29,15 @@ func (sms StateMachineState) LogKey() string {
 	return "state"
 }
 
+// Level returns the slog level of events in the state, so that the start and end of a game are info events.
+func (sms StateMachineState) Level() slog.Level {
+	switch sms {
+	case StateMachineStateGameStart, StateMachineStateGameEnd:
+		return slog.LevelInfo
+	}
+	return slog.LevelDebug
+}
+
 // StateRunner is a function that executes one step of the state machine, then transitions to the next
 type StateRunner func(gs *GameState) StateRunner
 
@@ -41,21 +54,22 @@ func (gs *GameState) Run() {
 
 // GameStart logs some things, then transitions to the initial build phase
 func GameStart(gs *GameState) StateRunner {
-	gs.Logger.Event().
-		With(GameLogEventStateMachineTransition, StateMachineStateGameStart).
-		WithKey("game_parameters", gs.Params).
-		WithKey("num_players", len(gs.Players)).
-		Log()
+	logPayload(gs.Logger.Event().With(StateMachineStateGameStart), GameStartEvent{
+		Params:     gs.Params,
+		NumPlayers: len(gs.Players),
+		RNGSeed:    gs.rngSeed,
+	})
 	return BuildPhase
 }
 
 // GameEnd logs some stats, then exits the state machine
 func GameEnd(gs *GameState) StateRunner {
-	gs.Logger.Event().
-		With(GameLogEventStateMachineTransition, StateMachineStateGameEnd, gs.Status, gs.Reason).
-		WithKey("total_emissions", gs.CarbonEmissions).
-		WithKey("players", gs.Players).
-		Log()
+	logPayload(gs.Logger.Event().With(StateMachineStateGameEnd), GameEndEvent{
+		Status:         gs.Status,
+		Reason:         gs.Reason,
+		TotalEmissions: gs.CarbonEmissions,
+		Players:        gs.Players,
+	})
 	if gs.GameOverFunc != nil {
 		gs.GameOverFunc()
 	}
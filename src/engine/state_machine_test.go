@@ -1,7 +1,9 @@
 package engine
 
 import (
+	"encoding/json"
 	"slices"
+	"strings"
 	"testing"
 
 	"github.com/WillMorrison/JouleQuestCardGame/core"
@@ -35,3 +37,28 @@ func Test_Run_E2E_NoopPlayers(t *testing.T) {
 		t.Errorf("Game status was %s: %q, want %s: %q", game.Status.String(), game.Reason.String(), core.GameStatusLoss.String(), core.LossConditionCarbonEmissionsExceeded.String())
 	}
 }
+
+func Test_GameStart_LogsRNGSeed(t *testing.T) {
+	// Arrange
+	var buf strings.Builder
+	game, err := NewGame(2, params.Default, eventlog.NewJsonLogger(&buf), nil, nil)
+	if err != nil {
+		t.Fatalf("Couldn't create new game: %s", err)
+	}
+	game.SetRNGSeed(1234)
+
+	// Act
+	GameStart(game)
+
+	// Assert
+	var event struct {
+		GameEvent string `json:"game_event"`
+		RNGSeed   uint64 `json:"rng_seed"`
+	}
+	if err := json.Unmarshal([]byte(buf.String()), &event); err != nil {
+		t.Fatalf("Couldn't decode GameStart event %q: %s", buf.String(), err)
+	}
+	if event.GameEvent != GameLogEventStateMachineTransition.String() || event.RNGSeed != 1234 {
+		t.Errorf("GameStart event = %+v, want rng_seed 1234", event)
+	}
+}
@@ -0,0 +1,278 @@
+package eventlog
+
+import "slices"
+
+// teeLogger is a logger that logs each event to several loggers.
+type teeLogger struct {
+	loggers []Logger
+}
+
+var _ Logger = (*teeLogger)(nil)
+
+// NewTeeLogger returns a Logger which logs every event to each of the loggers.
+func NewTeeLogger(loggers ...Logger) Logger {
+	return &teeLogger{loggers: loggers}
+}
+
+func (l *teeLogger) Event() LogEvent {
+	e := make(teeEvent, len(l.loggers))
+	for i, sub := range l.loggers {
+		e[i] = sub.Event()
+	}
+	return e
+}
+
+func (l *teeLogger) Sub() Logger {
+	nl := &teeLogger{loggers: make([]Logger, len(l.loggers))}
+	for i, sub := range l.loggers {
+		nl.loggers[i] = sub.Sub()
+	}
+	return nl
+}
+
+func (l *teeLogger) Set(value ...Loggable) Logger {
+	for i, sub := range l.loggers {
+		l.loggers[i] = sub.Set(value...)
+	}
+	return l
+}
+
+func (l *teeLogger) SetKey(key string, value any) Logger {
+	for i, sub := range l.loggers {
+		l.loggers[i] = sub.SetKey(key, value)
+	}
+	return l
+}
+
+type teeEvent []LogEvent
+
+func (e teeEvent) WithKey(key string, value any) LogEvent {
+	for i, sub := range e {
+		e[i] = sub.WithKey(key, value)
+	}
+	return e
+}
+
+func (e teeEvent) With(values ...Loggable) LogEvent {
+	for i, sub := range e {
+		e[i] = sub.With(values...)
+	}
+	return e
+}
+
+func (e teeEvent) Log() {
+	for _, sub := range e {
+		sub.Log()
+	}
+}
+
+// Field is one value of an event, with the Loggable it was logged from, if any.
+type Field struct {
+	Key      string
+	Value    any      // The String of the Loggable, if there is one
+	Loggable Loggable // Nil for values logged by key
+}
+
+// AddFields adds the fields to the event in order.
+func AddFields(e LogEvent, fields []Field) LogEvent {
+	for _, f := range fields {
+		if f.Loggable != nil {
+			e = e.With(f.Loggable)
+		} else {
+			e = e.WithKey(f.Key, f.Value)
+		}
+	}
+	return e
+}
+
+// setField replaces the field with the same key, or adds f.
+func setField(fields []Field, f Field) []Field {
+	if i := slices.IndexFunc(fields, func(g Field) bool { return g.Key == f.Key }); i != -1 {
+		fields[i] = f
+		return fields
+	}
+	return append(fields, f)
+}
+
+func loggableField(v Loggable) Field {
+	return Field{Key: v.LogKey(), Value: v.String(), Loggable: v}
+}
+
+// fieldLogger is a logger that collects the fields of each event, including those set on the logger, and passes them
+// to a function when the event is logged.
+type fieldLogger struct {
+	fields []Field
+	log    func([]Field)
+}
+
+var _ Logger = (*fieldLogger)(nil)
+
+// NewFieldLogger returns a Logger which passes the fields of each event to log, starting with those set on the
+// logger. Each key appears once, with the value set last. It lets loggers which need whole events, such as filters or
+// other formats, be written without implementing LogEvent.
+func NewFieldLogger(log func([]Field)) Logger {
+	return &fieldLogger{log: log}
+}
+
+func (l *fieldLogger) Event() LogEvent {
+	return &fieldEvent{fields: slices.Clone(l.fields), log: l.log}
+}
+
+func (l *fieldLogger) Sub() Logger {
+	return &fieldLogger{fields: slices.Clone(l.fields), log: l.log}
+}
+
+func (l *fieldLogger) Set(value ...Loggable) Logger {
+	for _, v := range value {
+		l.fields = setField(l.fields, loggableField(v))
+	}
+	return l
+}
+
+func (l *fieldLogger) SetKey(key string, value any) Logger {
+	l.fields = setField(l.fields, Field{Key: key, Value: value})
+	return l
+}
+
+type fieldEvent struct {
+	fields []Field
+	log    func([]Field)
+}
+
+func (e *fieldEvent) WithKey(key string, value any) LogEvent {
+	e.fields = setField(e.fields, Field{Key: key, Value: value})
+	return e
+}
+
+func (e *fieldEvent) With(values ...Loggable) LogEvent {
+	for _, v := range values {
+		e.fields = setField(e.fields, loggableField(v))
+	}
+	return e
+}
+
+func (e *fieldEvent) Log() {
+	e.log(e.fields)
+}
+
+// EventFilter reports whether to log an event, given the values of its keys. Loggable values are given as their
+// String, as they are logged.
+type EventFilter func(values map[string]any) bool
+
+// KeepValues returns an EventFilter keeping events which include any of the loggable values, such as the
+// GameLogEvents of interest.
+func KeepValues(values ...Loggable) EventFilter {
+	return func(event map[string]any) bool {
+		for _, v := range values {
+			if event[v.LogKey()] == v.String() {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// KeepKeys returns an EventFilter keeping events which have any of the keys.
+func KeepKeys(keys ...string) EventFilter {
+	return func(event map[string]any) bool {
+		for _, key := range keys {
+			if _, ok := event[key]; ok {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// NewFilterLogger returns a Logger which logs the events that keep accepts to l, and drops the others.
+func NewFilterLogger(l Logger, keep EventFilter) Logger {
+	return NewFieldLogger(func(fields []Field) {
+		values := make(map[string]any, len(fields))
+		for _, f := range fields {
+			values[f.Key] = f.Value
+		}
+		if keep(values) {
+			AddFields(l.Event(), fields).Log()
+		}
+	})
+}
+
+// SampleGame returns sampled for a fraction rate of game seeds, and rest for the others, such as a logger filtered to
+// terminal events. The choice only depends on the seed, so a game replayed with the same seed is logged the same way.
+func SampleGame(seed uint64, rate float64, sampled, rest Logger) Logger {
+	// splitmix64, so that nearby seeds are sampled independently
+	z := seed + 0x9e3779b97f4a7c15
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	z ^= z >> 31
+	if float64(z>>11)/(1<<53) < rate {
+		return sampled
+	}
+	return rest
+}
+
+// BufferLogger is a Logger which holds events in memory until they are flushed to another logger. It holds at most a
+// fixed number of events, dropping the oldest to make room for new ones. Its sub loggers share the buffer.
+type BufferLogger struct {
+	fieldLogger
+	buf *eventBuffer
+}
+
+var _ Logger = (*BufferLogger)(nil)
+
+type eventBuffer struct {
+	target  Logger
+	events  [][]Field // Ring of held events, the oldest at start
+	start   int
+	dropped int
+}
+
+func (b *eventBuffer) add(fields []Field) {
+	if len(b.events) < cap(b.events) {
+		b.events = append(b.events, fields)
+		return
+	}
+	if len(b.events) == 0 {
+		b.dropped++
+		return
+	}
+	b.events[b.start] = fields
+	b.start = (b.start + 1) % len(b.events)
+	b.dropped++
+}
+
+// NewBufferLogger returns a BufferLogger which holds up to size events for l.
+func NewBufferLogger(l Logger, size int) *BufferLogger {
+	buf := &eventBuffer{target: l, events: make([][]Field, 0, max(size, 0))}
+	return &BufferLogger{fieldLogger: fieldLogger{log: buf.add}, buf: buf}
+}
+
+func (l *BufferLogger) Set(value ...Loggable) Logger {
+	l.fieldLogger.Set(value...)
+	return l
+}
+
+func (l *BufferLogger) SetKey(key string, value any) Logger {
+	l.fieldLogger.SetKey(key, value)
+	return l
+}
+
+// Len returns the number of events held.
+func (l *BufferLogger) Len() int {
+	return len(l.buf.events)
+}
+
+// Dropped returns the number of events dropped to make room for newer ones.
+func (l *BufferLogger) Dropped() int {
+	return l.buf.dropped
+}
+
+// Flush logs the held events to the buffer's logger, oldest first, and empties the buffer.
+func (l *BufferLogger) Flush() {
+	b := l.buf
+	for i := range b.events {
+		AddFields(b.target.Event(), b.events[(b.start+i)%len(b.events)]).Log()
+	}
+	b.events = b.events[:0]
+	b.start = 0
+}
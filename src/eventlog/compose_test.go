@@ -0,0 +1,86 @@
+package eventlog
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_TeeLogger(t *testing.T) {
+	buf1, buf2 := strings.Builder{}, strings.Builder{}
+	logger := NewTeeLogger(NewJsonLogger(&buf1), NewJsonLogger(&buf2))
+	logger.SetKey("round", 1)
+
+	logger.Sub().Set(TestLoggable{"state", "Build"}).Event().WithKey("key", "value").Log()
+	logger.Event().With(TestLoggable{"event", "Win"}).Log()
+
+	want := `{"key":"value","round":1,"state":"Build"}
+{"event":"Win","round":1}
+`
+	if buf1.String() != want || buf2.String() != want {
+		t.Errorf("got %q and %q, want %q for both", buf1.String(), buf2.String(), want)
+	}
+}
+
+func Test_FilterLogger(t *testing.T) {
+	buf := strings.Builder{}
+	logger := NewFilterLogger(NewJsonLogger(&buf), KeepValues(TestLoggable{"event", "Win"}, TestLoggable{"event", "Loss"}))
+	logger.SetKey("round", 1)
+
+	logger.Event().With(TestLoggable{"event", "Action"}).Log()
+	logger.Event().With(TestLoggable{"event", "Loss"}).WithKey("money", -1).Log()
+	logger.Sub().Set(TestLoggable{"event", "Win"}).Event().Log()
+
+	want := `{"event":"Loss","money":-1,"round":1}
+{"event":"Win","round":1}
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func Test_KeepKeys(t *testing.T) {
+	keep := KeepKeys("a", "b")
+
+	if !keep(map[string]any{"b": 1, "c": 2}) || keep(map[string]any{"c": 2}) {
+		t.Errorf("KeepKeys(a, b) should keep events with b and drop events without a or b")
+	}
+}
+
+func Test_SampleGame(t *testing.T) {
+	sampled, rest := NewJsonLogger(nil), NewJsonLogger(nil)
+	var n int
+	for seed := range uint64(10000) {
+		if SampleGame(seed, 0.01, sampled, rest) == sampled {
+			n++
+		}
+		if SampleGame(seed, 0, sampled, rest) != rest || SampleGame(seed, 1, sampled, rest) != sampled {
+			t.Fatalf("Seed %d was sampled at rate 0 or not sampled at rate 1", seed)
+		}
+	}
+
+	if n < 50 || n > 150 {
+		t.Errorf("Sampled %d of 10000 games at rate 0.01, want about 100", n)
+	}
+}
+
+func Test_BufferLogger(t *testing.T) {
+	buf := strings.Builder{}
+	logger := NewBufferLogger(NewJsonLogger(&buf), 2)
+	logger.SetKey("round", 1)
+
+	logger.Event().WithKey("n", 1).Log()
+	logger.Sub().SetKey("player", 0).Event().WithKey("n", 2).Log()
+	logger.Event().WithKey("n", 3).Log()
+	gotLen, gotDropped, before := logger.Len(), logger.Dropped(), buf.String()
+	logger.Flush()
+
+	if gotLen != 2 || gotDropped != 1 || before != "" {
+		t.Errorf("Before Flush, got %d events, %d dropped and log %q, want 2 events, 1 dropped and an empty log", gotLen, gotDropped, before)
+	}
+	want := `{"n":2,"player":0,"round":1}
+{"n":3,"round":1}
+`
+	if got := buf.String(); got != want || logger.Len() != 0 {
+		t.Errorf("After Flush, got %q with %d events held, want %q with none held", got, logger.Len(), want)
+	}
+}
@@ -2,9 +2,11 @@
 package eventlog
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
+	"sync"
 )
 
 // Loggable represents a value that can be logged as a string and knows its own key. Useful for logging enums.
@@ -46,22 +48,23 @@ func (e nullEvent) WithKey(key string, value any) LogEvent { return e }
 func (e nullEvent) With(value ...Loggable) LogEvent        { return e }
 func (e nullEvent) Log()                                   {}
 
-// WriterLogger is a logger that writes log events using a provided io.Writer.
+// jsonLogger is a logger that writes each log event as a line of JSON to an io.Writer. Each event is written with a
+// single Write, so the logger and its sub loggers are safe for concurrent use if the writer is.
 type jsonLogger struct {
-	data   map[string]any
-	writer io.Writer
+	data map[string]any
+	w    io.Writer
 }
 
 var _ Logger = (*jsonLogger)(nil)
 
 func NewJsonLogger(w io.Writer) Logger {
-	return &jsonLogger{writer: w}
+	return &jsonLogger{w: w}
 }
 
 // Event starts a new log event.
 func (l *jsonLogger) Event() LogEvent {
 	var e = jsonLogEvent{
-		encoder: json.NewEncoder(l.writer),
+		w: l.w,
 	}
 	if l.data != nil {
 		e.data = make(map[string]any)
@@ -94,7 +97,7 @@ func (l *jsonLogger) SetKey(key string, value any) Logger {
 
 // Sub creates a new logger that includes the same provided values as this logger.
 func (l jsonLogger) Sub() Logger {
-	nl := NewJsonLogger(l.writer)
+	nl := &jsonLogger{w: l.w}
 	for k, v := range l.data {
 		nl.SetKey(k, v)
 	}
@@ -102,8 +105,8 @@ func (l jsonLogger) Sub() Logger {
 }
 
 type jsonLogEvent struct {
-	data    map[string]any
-	encoder *json.Encoder
+	data map[string]any
+	w    io.Writer
 }
 
 var _ LogEvent = (*jsonLogEvent)(nil)
@@ -126,8 +129,26 @@ func (e *jsonLogEvent) With(values ...Loggable) LogEvent {
 	return e
 }
 
+// jsonLine is a buffer for encoding one event, reused through jsonLines.
+type jsonLine struct {
+	buf     bytes.Buffer
+	encoder *json.Encoder
+}
+
+var jsonLines = sync.Pool{New: func() any {
+	l := &jsonLine{}
+	l.encoder = json.NewEncoder(&l.buf)
+	return l
+}}
+
 func (e *jsonLogEvent) Log() {
-	if e.encoder != nil {
-		e.encoder.Encode(e.data)
+	if e.w == nil {
+		return
+	}
+	l := jsonLines.Get().(*jsonLine)
+	defer jsonLines.Put(l)
+	l.buf.Reset()
+	if l.encoder.Encode(e.data) == nil {
+		e.w.Write(l.buf.Bytes())
 	}
 }
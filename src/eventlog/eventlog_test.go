@@ -1,7 +1,9 @@
 package eventlog
 
 import (
+	"encoding/json"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -168,6 +170,44 @@ func Test_JsonLogger_Sub(t *testing.T) {
 	}
 }
 
+// writeRecorder records the data of each call to Write.
+type writeRecorder struct {
+	mu     sync.Mutex
+	writes []string
+}
+
+func (w *writeRecorder) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.writes = append(w.writes, string(p))
+	return len(p), nil
+}
+
+func Test_JsonLogger_Sub_Concurrent(t *testing.T) {
+	w := &writeRecorder{}
+	logger := NewJsonLogger(w)
+	var wg sync.WaitGroup
+	for g := range 8 {
+		sub := logger.Sub().SetKey("goroutine", g)
+		wg.Go(func() {
+			for i := range 100 {
+				WithFields(sub.Event().WithKey("event", i), testFields{Enum: testEnumB, Count: i}).Log()
+			}
+		})
+	}
+	wg.Wait()
+
+	if len(w.writes) != 800 {
+		t.Fatalf("Got %d writes, want one per event", len(w.writes))
+	}
+	for _, line := range w.writes {
+		var event map[string]any
+		if !strings.HasSuffix(line, "\n") || json.Unmarshal([]byte(line), &event) != nil || len(event) != 4 {
+			t.Fatalf("Write %q is not one whole event", line)
+		}
+	}
+}
+
 func Test_NullLogger_NoPanic(t *testing.T) {
 	logger := NullLogger{}
 	subLogger := logger.
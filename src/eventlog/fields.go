@@ -0,0 +1,139 @@
+package eventlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Enum is a Loggable enum generated by stringer, whose values count up from 0.
+type Enum interface {
+	~int
+	Loggable
+}
+
+// maxEnumValues bounds the search for enum values, in case a String method never reports an invalid value.
+const maxEnumValues = 1 << 10
+
+// EnumNames returns the names of every value of the enum type t, which must have an int kind and a String method.
+// stringer names the first value outside the enum "Type(n)", which ends the list.
+func EnumNames(t reflect.Type) []string {
+	var names []string
+	v := reflect.New(t).Elem()
+	for i := range maxEnumValues {
+		v.SetInt(int64(i))
+		name := v.Interface().(fmt.Stringer).String()
+		if name == fmt.Sprintf("%s(%d)", t.Name(), i) {
+			break
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// ParseEnum returns the value of an enum with the given name, as logged by With.
+func ParseEnum[T Enum](name string) (T, error) {
+	var v T
+	if err := parseEnum(reflect.ValueOf(&v).Elem(), name); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+func parseEnum(v reflect.Value, name string) error {
+	for i, n := range EnumNames(v.Type()) {
+		if n == name {
+			v.SetInt(int64(i))
+			return nil
+		}
+	}
+	return fmt.Errorf("%q is not a valid %s", name, v.Type().Name())
+}
+
+// FieldKey returns the key a struct field is logged under by WithFields, and whether it may be left out. Fields are
+// tagged `log:"key"`, or `log:"key,optional"` for fields which only some events have. Pointer and slice fields are
+// always optional. It returns "" for fields without a tag.
+func FieldKey(f reflect.StructField) (string, bool) {
+	key, opts, _ := strings.Cut(f.Tag.Get("log"), ",")
+	optional := opts == "optional" || f.Type.Kind() == reflect.Pointer || f.Type.Kind() == reflect.Slice
+	return key, optional
+}
+
+var loggableType = reflect.TypeFor[Loggable]()
+
+// IsLoggableField reports whether a field of type t is logged by name, like the values passed to With. Pointers to
+// Loggable values are too.
+func IsLoggableField(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Int && t.Implements(loggableType)
+}
+
+// WithFields adds each tagged field of the struct v to the event. Loggable fields are logged by name like With, and
+// other fields like WithKey. Nil pointer and slice fields are left out. The fields become ordinary keys of the event,
+// so the struct only types the keys: events are encoded, and decoded by DecodeFields, as key-value objects.
+func WithFields(e LogEvent, v any) LogEvent {
+	rv := reflect.ValueOf(v)
+	for i := range rv.NumField() {
+		key, _ := FieldKey(rv.Type().Field(i))
+		if key == "" {
+			continue
+		}
+		f := rv.Field(i)
+		switch f.Kind() {
+		case reflect.Pointer, reflect.Slice:
+			if f.IsNil() {
+				continue
+			}
+		}
+		if f.Kind() == reflect.Pointer {
+			f = f.Elem()
+		}
+		if IsLoggableField(f.Type()) {
+			e = e.WithKey(key, f.Interface().(Loggable).String())
+		} else {
+			e = e.WithKey(key, f.Interface())
+		}
+	}
+	return e
+}
+
+// DecodeFields sets the tagged fields of the struct pointed to by v from the event, reversing WithFields. It fails if
+// the event lacks a key for a field which isn't optional.
+func (e Event) DecodeFields(v any) error {
+	rv := reflect.ValueOf(v).Elem()
+	for i := range rv.NumField() {
+		key, optional := FieldKey(rv.Type().Field(i))
+		if key == "" {
+			continue
+		}
+		raw, ok := e[key]
+		if !ok {
+			if optional {
+				continue
+			}
+			return fmt.Errorf("event has no %q key", key)
+		}
+		f := rv.Field(i)
+		if !IsLoggableField(f.Type()) {
+			if err := json.Unmarshal(raw, f.Addr().Interface()); err != nil {
+				return fmt.Errorf("cannot decode %q: %w", key, err)
+			}
+			continue
+		}
+		if f.Kind() == reflect.Pointer {
+			f.Set(reflect.New(f.Type().Elem()))
+			f = f.Elem()
+		}
+		var name string
+		if err := json.Unmarshal(raw, &name); err != nil {
+			return fmt.Errorf("cannot decode %q: %w", key, err)
+		}
+		if err := parseEnum(f, name); err != nil {
+			return fmt.Errorf("cannot decode %q: %w", key, err)
+		}
+	}
+	return nil
+}
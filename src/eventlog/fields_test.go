@@ -0,0 +1,101 @@
+package eventlog
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type testEnum int
+
+const (
+	testEnumA testEnum = iota
+	testEnumB
+)
+
+func (te testEnum) String() string {
+	switch te {
+	case testEnumA:
+		return "A"
+	case testEnumB:
+		return "B"
+	}
+	return "testEnum(" + strconv.Itoa(int(te)) + ")"
+}
+
+func (te testEnum) LogKey() string {
+	return "test_enum"
+}
+
+type testFields struct {
+	Enum     testEnum  `log:"enum"`
+	OptEnum  *testEnum `log:"opt_enum"`
+	Count    int       `log:"count,optional"`
+	List     []string  `log:"list"`
+	Untagged int
+}
+
+func Test_EnumNames(t *testing.T) {
+	got := EnumNames(reflect.TypeFor[testEnum]())
+
+	if want := []string{"A", "B"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("EnumNames() = %q, want %q", got, want)
+	}
+}
+
+func Test_ParseEnum(t *testing.T) {
+	if got, err := ParseEnum[testEnum]("B"); err != nil || got != testEnumB {
+		t.Errorf("ParseEnum(B) = %v, %v, want B", got, err)
+	}
+	if _, err := ParseEnum[testEnum]("C"); err == nil {
+		t.Errorf("ParseEnum(C) succeeded, want error")
+	}
+}
+
+func Test_WithFields(t *testing.T) {
+	buf := strings.Builder{}
+	b := testEnumB
+
+	WithFields(NewJsonLogger(&buf).Event(), testFields{Enum: testEnumA, OptEnum: &b, Count: 2, Untagged: 3}).Log()
+
+	want := `{"count":2,"enum":"A","opt_enum":"B"}
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func Test_Event_DecodeFields(t *testing.T) {
+	b := testEnumB
+	tests := []struct {
+		name    string
+		event   string
+		want    testFields
+		wantErr bool
+	}{
+		{name: "all keys", event: `{"enum":"B","opt_enum":"B","count":2,"list":["x"]}`, want: testFields{Enum: testEnumB, OptEnum: &b, Count: 2, List: []string{"x"}}},
+		{name: "only required keys", event: `{"enum":"A"}`, want: testFields{}},
+		{name: "missing required key", event: `{"count":2}`, wantErr: true},
+		{name: "invalid enum", event: `{"enum":"C"}`, wantErr: true},
+		{name: "wrong type", event: `{"enum":"A","count":"2"}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := DecodeEvent([]byte(tt.event))
+			if err != nil {
+				t.Fatalf("DecodeEvent() error = %v", err)
+			}
+			var got testFields
+
+			err = e.DecodeFields(&got)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeFields() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package eventlog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// maxEventSize is the longest line a Reader accepts. GameEnd events list every player, and GameStart events include
+// the game parameters, so lines can be much longer than bufio's default.
+const maxEventSize = 16 << 20
+
+// Event is one event of a JSON log, as written by a Logger from NewJsonLogger. Values are kept as raw JSON, so that
+// they can be decoded into the types they were logged from.
+type Event map[string]json.RawMessage
+
+// DecodeEvent decodes one line of a JSON log.
+func DecodeEvent(line []byte) (Event, error) {
+	var e Event
+	if err := json.Unmarshal(line, &e); err != nil {
+		return nil, err
+	}
+	if e == nil {
+		return nil, fmt.Errorf("event is null")
+	}
+	return e, nil
+}
+
+// Text returns the value of a key holding a string, such as the keys of Loggable values, or "" if the event doesn't
+// have it.
+func (e Event) Text(key string) string {
+	var s string
+	if err := json.Unmarshal(e[key], &s); err != nil {
+		return ""
+	}
+	return s
+}
+
+// Has reports whether the event includes all of the loggable values.
+func (e Event) Has(values ...Loggable) bool {
+	for _, v := range values {
+		if e.Text(v.LogKey()) != v.String() {
+			return false
+		}
+	}
+	return true
+}
+
+// Decode decodes the value of the key into v.
+func (e Event) Decode(key string, v any) error {
+	raw, ok := e[key]
+	if !ok {
+		return fmt.Errorf("event has no %q key", key)
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("cannot decode %q: %w", key, err)
+	}
+	return nil
+}
+
+// Equal reports whether both events have the same keys with the same JSON values, ignoring whitespace.
+func (e Event) Equal(other Event) bool {
+	if len(e) != len(other) {
+		return false
+	}
+	for k, v := range e {
+		ov, ok := other[k]
+		if !ok {
+			return false
+		}
+		var a, b bytes.Buffer
+		if json.Compact(&a, v) != nil || json.Compact(&b, ov) != nil || !bytes.Equal(a.Bytes(), b.Bytes()) {
+			return false
+		}
+	}
+	return true
+}
+
+// Reader reads the events of a JSON log, one per line.
+type Reader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func NewReader(r io.Reader) *Reader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxEventSize)
+	return &Reader{scanner: scanner}
+}
+
+// Next returns the next event of the log, skipping blank lines. It returns io.EOF after the last event.
+func (r *Reader) Next() (Event, error) {
+	for r.scanner.Scan() {
+		r.line++
+		line := bytes.TrimSpace(r.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		e, err := DecodeEvent(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", r.line, err)
+		}
+		return e, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, fmt.Errorf("line %d: %w", r.line+1, err)
+	}
+	return nil, io.EOF
+}
+
+// Line returns the line number of the event last returned by Next, starting at 1.
+func (r *Reader) Line() int {
+	return r.line
+}
@@ -0,0 +1,71 @@
+package eventlog
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func Test_Reader_Next(t *testing.T) {
+	buf := strings.Builder{}
+	logger := NewJsonLogger(&buf)
+	logger.Set(TestLoggable{Key: "state", Value: "Build"})
+	logger.Event().WithKey("round", 3).Log()
+	buf.WriteString("\n")
+	logger.Event().With(TestLoggable{Key: "event", Value: "Win"}).Log()
+	r := NewReader(strings.NewReader(buf.String()))
+
+	first, err1 := r.Next()
+	line1 := r.Line()
+	second, err2 := r.Next()
+	line2 := r.Line()
+	_, err3 := r.Next()
+
+	var round int
+	if err1 != nil || line1 != 1 || first.Decode("round", &round) != nil || round != 3 || first.Text("state") != "Build" {
+		t.Errorf("first Next() = %v, %v at line %d, want round 3 and state Build at line 1", first, err1, line1)
+	}
+	if err2 != nil || line2 != 3 || !second.Has(TestLoggable{Key: "event", Value: "Win"}, TestLoggable{Key: "state", Value: "Build"}) {
+		t.Errorf("second Next() = %v, %v at line %d, want event Win and state Build at line 3", second, err2, line2)
+	}
+	if !errors.Is(err3, io.EOF) {
+		t.Errorf("third Next() error = %v, want io.EOF", err3)
+	}
+}
+
+func Test_Reader_Next_Invalid(t *testing.T) {
+	r := NewReader(strings.NewReader("{\"a\":1}\nnot json\n"))
+	r.Next()
+
+	_, err := r.Next()
+
+	if err == nil || !strings.HasPrefix(err.Error(), "line 2:") {
+		t.Errorf("Next() error = %v, want an error for line 2", err)
+	}
+}
+
+func Test_Event_Equal(t *testing.T) {
+	a, _ := DecodeEvent([]byte(`{"a":{"x":1,"y":[1,2]},"b":"c"}`))
+	tests := []struct {
+		name  string
+		other string
+		want  bool
+	}{
+		{name: "whitespace", other: `{ "b": "c", "a": {"x": 1, "y": [1, 2]} }`, want: true},
+		{name: "different value", other: `{"a":{"x":1,"y":[1,3]},"b":"c"}`, want: false},
+		{name: "missing key", other: `{"a":{"x":1,"y":[1,2]}}`, want: false},
+		{name: "other key", other: `{"a":{"x":1,"y":[1,2]},"d":"c"}`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other, err := DecodeEvent([]byte(tt.other))
+			if err != nil {
+				t.Fatalf("DecodeEvent() error = %v", err)
+			}
+			if got := a.Equal(other); got != tt.want {
+				t.Errorf("Equal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package eventlog
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// Leveled is a Loggable which sets the slog level of the events it is logged with.
+type Leveled interface {
+	Loggable
+	Level() slog.Level
+}
+
+// slogLogger is a logger that logs each event as a record to an slog.Handler.
+type slogLogger struct {
+	base    slog.Handler
+	handler slog.Handler // base with attrs
+	attrs   slogAttrs    // Set on this logger
+	msgKey  string
+	msg     string // Value set under msgKey
+}
+
+var _ Logger = (*slogLogger)(nil)
+
+// NewSlogLogger returns a Logger which logs each event as a record to the handler. Loggable values become string
+// attributes under their LogKey, and the value under msgKey, such as "game_event", is also the record's message, which
+// is empty for events without one.
+// Events are logged at the highest Level of their Leveled values, or at slog.LevelInfo if they have none. Set and
+// SetKey add attributes to the handler, like slog.Logger.With, but replace earlier values for the same key, as do the
+// values added to an event.
+func NewSlogLogger(h slog.Handler, msgKey string) Logger {
+	return &slogLogger{base: h, handler: h, msgKey: msgKey}
+}
+
+// Event starts a new log event.
+func (l *slogLogger) Event() LogEvent {
+	return &slogEvent{logger: *l, msg: l.msg}
+}
+
+// Set sets one or more loggable values that will be included in all subsequent log events.
+func (l *slogLogger) Set(value ...Loggable) Logger {
+	for _, v := range value {
+		l.attrs.setLoggable(v)
+		if v.LogKey() == l.msgKey {
+			l.msg = v.String()
+		}
+	}
+	l.handler = l.base.WithAttrs(l.attrs.slogAttrs())
+	return l
+}
+
+// SetKey sets a key-value pair that will be included in all subsequent log events.
+func (l *slogLogger) SetKey(key string, value any) Logger {
+	l.attrs.set(slogAttr{Attr: slog.Any(key, value)})
+	l.handler = l.base.WithAttrs(l.attrs.slogAttrs())
+	return l
+}
+
+// Sub creates a new logger that includes the same provided values as this logger.
+func (l *slogLogger) Sub() Logger {
+	nl := *l
+	nl.attrs = slices.Clone(l.attrs)
+	return &nl
+}
+
+// slogAttr is an attribute, with the level of the value it was set from if that is Leveled.
+type slogAttr struct {
+	slog.Attr
+	level *slog.Level
+}
+
+// slogAttrs holds at most one attribute per key.
+type slogAttrs []slogAttr
+
+// set adds the attribute, replacing any attribute with the same key.
+func (as *slogAttrs) set(a slogAttr) {
+	if i := as.index(a.Key); i != -1 {
+		(*as)[i] = a
+	} else {
+		*as = append(*as, a)
+	}
+}
+
+// setLoggable adds the loggable value as a string attribute under its LogKey, with its level if it is Leveled.
+func (as *slogAttrs) setLoggable(v Loggable) {
+	a := slogAttr{Attr: slog.String(v.LogKey(), v.String())}
+	if lv, ok := v.(Leveled); ok {
+		level := lv.Level()
+		a.level = &level
+	}
+	as.set(a)
+}
+
+func (as slogAttrs) index(key string) int {
+	return slices.IndexFunc(as, func(a slogAttr) bool { return a.Key == key })
+}
+
+// level returns the highest level of the attributes, or nil if none has one.
+func (as slogAttrs) level() *slog.Level {
+	var level *slog.Level
+	for _, a := range as {
+		if a.level != nil && (level == nil || *a.level > *level) {
+			level = a.level
+		}
+	}
+	return level
+}
+
+func (as slogAttrs) slogAttrs() []slog.Attr {
+	attrs := make([]slog.Attr, len(as))
+	for i, a := range as {
+		attrs[i] = a.Attr
+	}
+	return attrs
+}
+
+type slogEvent struct {
+	logger    slogLogger
+	attrs     slogAttrs
+	overrides bool // Whether attrs replace any of the logger's attributes
+	msg       string
+}
+
+var _ LogEvent = (*slogEvent)(nil)
+
+func (e *slogEvent) set(a slogAttr) {
+	e.attrs.set(a)
+	e.overrides = e.overrides || e.logger.attrs.index(a.Key) != -1
+}
+
+func (e *slogEvent) WithKey(key string, value any) LogEvent {
+	e.set(slogAttr{Attr: slog.Any(key, value)})
+	return e
+}
+
+func (e *slogEvent) With(values ...Loggable) LogEvent {
+	for _, v := range values {
+		var attrs slogAttrs
+		attrs.setLoggable(v)
+		e.set(attrs[0])
+		if v.LogKey() == e.logger.msgKey {
+			e.msg = v.String()
+		}
+	}
+	return e
+}
+
+func (e *slogEvent) Log() {
+	// The logger's handler already has its attributes, unless the event replaces some of them
+	handler, attrs := e.logger.handler, e.attrs
+	if e.overrides {
+		handler, attrs = e.logger.base, slices.Clone(e.logger.attrs)
+		for _, a := range e.attrs {
+			attrs.set(a)
+		}
+	}
+	levels := attrs.level()
+	if !e.overrides {
+		if l := e.logger.attrs.level(); levels == nil || (l != nil && *l > *levels) {
+			levels = l
+		}
+	}
+	level := slog.LevelInfo
+	if levels != nil {
+		level = *levels
+	}
+	ctx := context.Background()
+	if !handler.Enabled(ctx, level) {
+		return
+	}
+	r := slog.NewRecord(time.Now(), level, e.msg, 0)
+	r.AddAttrs(attrs.slogAttrs()...)
+	handler.Handle(ctx, r)
+}
+
+// loggerHandler is an slog.Handler which logs records as events to a Logger.
+type loggerHandler struct {
+	logger Logger
+	level  slog.Leveler
+	prefix string // Prefix of attribute keys, from groups
+}
+
+var _ slog.Handler = loggerHandler{}
+
+// NewLoggerHandler returns an slog.Handler which logs each record of at least the given level as an event to the
+// Logger, so that log/slog output can be written to game logs. The record's time, level and message are logged
+// under slog.TimeKey, slog.LevelKey and slog.MessageKey. Attributes in groups are logged under keys joined by dots.
+func NewLoggerHandler(l Logger, level slog.Leveler) slog.Handler {
+	return loggerHandler{logger: l, level: level}
+}
+
+func (h loggerHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h loggerHandler) Handle(_ context.Context, r slog.Record) error {
+	e := h.logger.Event()
+	if !r.Time.IsZero() {
+		e = e.WithKey(slog.TimeKey, r.Time)
+	}
+	e = e.WithKey(slog.LevelKey, r.Level.String()).WithKey(slog.MessageKey, r.Message)
+	r.Attrs(func(a slog.Attr) bool {
+		h.addAttr(a, h.prefix, func(key string, value any) { e = e.WithKey(key, value) })
+		return true
+	})
+	e.Log()
+	return nil
+}
+
+// addAttr calls add for the attribute, or for each attribute of a group.
+func (h loggerHandler) addAttr(a slog.Attr, prefix string, add func(key string, value any)) {
+	v := a.Value.Resolve()
+	if v.Kind() != slog.KindGroup {
+		if a.Key != "" {
+			add(prefix+a.Key, v.Any())
+		}
+		return
+	}
+	if a.Key != "" {
+		prefix += a.Key + "."
+	}
+	for _, ga := range v.Group() {
+		h.addAttr(ga, prefix, add)
+	}
+}
+
+func (h loggerHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	logger := h.logger.Sub()
+	for _, a := range attrs {
+		h.addAttr(a, h.prefix, func(key string, value any) { logger = logger.SetKey(key, value) })
+	}
+	return loggerHandler{logger: logger, level: h.level, prefix: h.prefix}
+}
+
+func (h loggerHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return loggerHandler{logger: h.logger, level: h.level, prefix: h.prefix + name + "."}
+}
@@ -0,0 +1,130 @@
+package eventlog
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testLeveled struct {
+	TestLoggable
+	level slog.Level
+}
+
+func (tl testLeveled) Level() slog.Level {
+	return tl.level
+}
+
+// newTestSlogLogger returns a Logger writing JSON records without times at the given level and above to buf.
+func newTestSlogLogger(buf *strings.Builder, level slog.Level) Logger {
+	h := slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	return NewSlogLogger(h, "event")
+}
+
+func Test_SlogLogger_Event(t *testing.T) {
+	buf := strings.Builder{}
+	logger := newTestSlogLogger(&buf, slog.LevelDebug)
+	logger.SetKey("round", 1).Set(testLeveled{TestLoggable{"state", "Build"}, slog.LevelDebug})
+	logger.SetKey("round", 2)
+
+	logger.Event().With(testLeveled{TestLoggable{"event", "Loses"}, slog.LevelWarn}).WithKey("money", -1).Log()
+	logger.Sub().SetKey("player", 0).Event().WithKey("key", "value").Log()
+	logger.Event().With(TestLoggable{"other", "x"}).Log()
+
+	want := `{"level":"WARN","msg":"Loses","round":2,"state":"Build","event":"Loses","money":-1}
+{"level":"DEBUG","msg":"","round":2,"state":"Build","player":0,"key":"value"}
+{"level":"DEBUG","msg":"","round":2,"state":"Build","other":"x"}
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func Test_SlogLogger_Level(t *testing.T) {
+	buf := strings.Builder{}
+	logger := newTestSlogLogger(&buf, slog.LevelInfo)
+
+	logger.Event().With(testLeveled{TestLoggable{"event", "Debug"}, slog.LevelDebug}).Log()
+	logger.Event().WithKey("no", "level").Log()
+
+	want := `{"level":"INFO","msg":"","no":"level"}
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func Test_SlogLogger_Event_ReplacesLoggerKeys(t *testing.T) {
+	buf := strings.Builder{}
+	logger := newTestSlogLogger(&buf, slog.LevelDebug)
+	logger.SetKey("round", 1).Set(testLeveled{TestLoggable{"state", "Build"}, slog.LevelWarn})
+
+	logger.Event().WithKey("round", 2).With(testLeveled{TestLoggable{"state", "Operate"}, slog.LevelDebug}).Log()
+	logger.Event().WithKey("key", "value").Log()
+
+	want := `{"level":"DEBUG","msg":"","round":2,"state":"Operate"}
+{"level":"WARN","msg":"","round":1,"state":"Build","key":"value"}
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func Test_SlogLogger_Set_ReplacesLevel(t *testing.T) {
+	buf := strings.Builder{}
+	logger := newTestSlogLogger(&buf, slog.LevelDebug)
+
+	logger.Set(testLeveled{TestLoggable{"state", "Build"}, slog.LevelWarn})
+	logger.Set(testLeveled{TestLoggable{"state", "Operate"}, slog.LevelDebug}).Event().Log()
+	logger.Set(testLeveled{TestLoggable{"state", "End"}, slog.LevelWarn}).SetKey("state", "none").Event().Log()
+
+	want := `{"level":"DEBUG","msg":"","state":"Operate"}
+{"level":"INFO","msg":"","state":"none"}
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func Test_LoggerHandler(t *testing.T) {
+	buf := strings.Builder{}
+	logger := slog.New(NewLoggerHandler(NewJsonLogger(&buf), slog.LevelInfo))
+
+	logger.Debug("hidden")
+	logger.With("a", 1).WithGroup("g").With("b", 2).Info("hello", "c", 3, slog.Group("d", "e", 4))
+
+	got := buf.String()
+	if strings.Contains(got, "hidden") {
+		t.Errorf("Debug record was logged: %s", got)
+	}
+	for _, want := range []string{`"a":1`, `"g.b":2`, `"g.c":3`, `"g.d.e":4`, `"level":"INFO"`, `"msg":"hello"`, `"time":`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("got %s, want it to contain %s", got, want)
+		}
+	}
+}
+
+func Test_LoggerHandler_Record(t *testing.T) {
+	buf := strings.Builder{}
+	h := NewLoggerHandler(NewJsonLogger(&buf), slog.LevelInfo)
+	r := slog.NewRecord(time.Time{}, slog.LevelWarn, "message", 0)
+	r.AddAttrs(slog.String("key", "value"))
+
+	h.Handle(context.Background(), r)
+
+	want := `{"key":"value","level":"WARN","msg":"message"}
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,277 @@
+// Package binproto implements the binary protocol served by cmd/rest_api with -protocol=binary. It offers the
+// operations of the REST API, with the same messages, but encodes them compactly instead of as JSON. Streaming game
+// events (GET /g/{id}/events) has no equivalent, since each request gets exactly one response; clients poll GetState
+// with Wait or GetLog with an Offset instead. The message types
+// in messages.go are the single definition of the protocol, used both by the server and to generate the Python client
+// with cmd/binproto_pybindgen.
+//
+// Each message is sent as a frame: the length of the encoded message as a little-endian uint32, then the message. A
+// request is the Op as one byte, followed by the op's request message. A response is an HTTP status code as an int32,
+// followed by the op's response message if the status is 200, or by an ErrorResponse otherwise. A response that would
+// not fit in MaxFrameSize is replaced by a 500 ErrorResponse. Requests on one connection are handled one at a time, in
+// order.
+//
+// Messages are Go structs whose fields are encoded in order, without names:
+//   - bool: one byte, 0 or 1
+//   - int32, int64, uint64: little-endian, 4 or 8 bytes
+//   - string: its length in bytes as a uint32, then the bytes
+//   - slice: its length as a uint32, then each element
+//   - pointer: a bool for whether it is non-nil, then the value if it is
+//   - struct: each field in declaration order
+package binproto
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// MaxFrameSize limits the size of a frame, so that a corrupt length can't make the reader allocate without bound.
+const MaxFrameSize = 16 << 20
+
+// WriteFrame writes one frame holding the payload.
+func WriteFrame(w io.Writer, payload []byte) error {
+	if len(payload) > MaxFrameSize {
+		return fmt.Errorf("frame of %d bytes exceeds the maximum of %d", len(payload), MaxFrameSize)
+	}
+	frame := binary.LittleEndian.AppendUint32(make([]byte, 0, 4+len(payload)), uint32(len(payload)))
+	_, err := w.Write(append(frame, payload...))
+	return err
+}
+
+// ReadFrame reads one frame and returns its payload. It returns io.EOF if the reader ends cleanly before a frame.
+func ReadFrame(r io.Reader) ([]byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	size := binary.LittleEndian.Uint32(header[:])
+	if size > MaxFrameSize {
+		return nil, fmt.Errorf("frame of %d bytes exceeds the maximum of %d", size, MaxFrameSize)
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return payload, nil
+}
+
+// EncodeRequest returns the payload of a request frame for the op.
+func EncodeRequest(op Op, req any) ([]byte, error) {
+	return Append([]byte{byte(op)}, req)
+}
+
+// DecodeRequest returns the op of a request frame's payload, and the encoding of its request message.
+func DecodeRequest(payload []byte) (Op, []byte, error) {
+	if len(payload) == 0 {
+		return 0, nil, errShortMessage
+	}
+	return Op(payload[0]), payload[1:], nil
+}
+
+// EncodeResponse returns the payload of a response frame. msg must be the op's response message if status is 200, or
+// an ErrorResponse otherwise.
+func EncodeResponse(status int32, msg any) ([]byte, error) {
+	return Append(binary.LittleEndian.AppendUint32(nil, uint32(status)), msg)
+}
+
+// DecodeResponse returns the status of a response frame's payload, and the encoding of its message.
+func DecodeResponse(payload []byte) (int32, []byte, error) {
+	if len(payload) < 4 {
+		return 0, nil, errShortMessage
+	}
+	return int32(binary.LittleEndian.Uint32(payload)), payload[4:], nil
+}
+
+// Append appends the encoding of the message v to buf. It returns an error if v has a type the protocol can't encode.
+func Append(buf []byte, v any) ([]byte, error) {
+	return appendValue(buf, reflect.ValueOf(v))
+}
+
+// Marshal returns the encoding of the message v.
+func Marshal(v any) ([]byte, error) {
+	return Append(nil, v)
+}
+
+func appendLen(buf []byte, n int) []byte {
+	return binary.LittleEndian.AppendUint32(buf, uint32(n))
+}
+
+func appendValue(buf []byte, v reflect.Value) ([]byte, error) {
+	var err error
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return append(buf, 1), nil
+		}
+		return append(buf, 0), nil
+	case reflect.Int32:
+		return binary.LittleEndian.AppendUint32(buf, uint32(v.Int())), nil
+	case reflect.Int64:
+		return binary.LittleEndian.AppendUint64(buf, uint64(v.Int())), nil
+	case reflect.Uint64:
+		return binary.LittleEndian.AppendUint64(buf, v.Uint()), nil
+	case reflect.String:
+		return append(appendLen(buf, v.Len()), v.String()...), nil
+	case reflect.Slice:
+		buf = appendLen(buf, v.Len())
+		for i := range v.Len() {
+			if buf, err = appendValue(buf, v.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case reflect.Pointer:
+		if v.IsNil() {
+			return append(buf, 0), nil
+		}
+		return appendValue(append(buf, 1), v.Elem())
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if buf, err = appendValue(buf, v.Field(i)); err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", v.Type().Name(), v.Type().Field(i).Name, err)
+			}
+		}
+		return buf, nil
+	}
+	return nil, fmt.Errorf("cannot encode values of type %s", v.Type())
+}
+
+// Unmarshal decodes the message in data into the value that v points to. All of data must be used.
+func Unmarshal(data []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("cannot decode into non-pointer %T", v)
+	}
+	d := decoder{data: data}
+	if err := d.value(rv.Elem()); err != nil {
+		return err
+	}
+	if len(d.data) > 0 {
+		return fmt.Errorf("%d bytes left over after decoding %s", len(d.data), rv.Elem().Type())
+	}
+	return nil
+}
+
+var errShortMessage = errors.New("message is too short")
+
+type decoder struct {
+	data []byte
+}
+
+func (d *decoder) take(n int) ([]byte, error) {
+	if n > len(d.data) {
+		return nil, errShortMessage
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b, nil
+}
+
+// length reads a string or slice length. Every element takes at least one byte, so a length beyond the rest of the
+// data must be corrupt.
+func (d *decoder) length() (int, error) {
+	b, err := d.take(4)
+	if err != nil {
+		return 0, err
+	}
+	n := binary.LittleEndian.Uint32(b)
+	if uint64(n) > uint64(len(d.data)) {
+		return 0, errShortMessage
+	}
+	return int(n), nil
+}
+
+func (d *decoder) bool() (bool, error) {
+	b, err := d.take(1)
+	if err != nil {
+		return false, err
+	}
+	switch b[0] {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	}
+	return false, fmt.Errorf("invalid bool value %d", b[0])
+}
+
+func (d *decoder) value(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Bool:
+		b, err := d.bool()
+		v.SetBool(b)
+		return err
+	case reflect.Int32:
+		b, err := d.take(4)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(int32(binary.LittleEndian.Uint32(b))))
+		return nil
+	case reflect.Int64:
+		b, err := d.take(8)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(binary.LittleEndian.Uint64(b)))
+		return nil
+	case reflect.Uint64:
+		b, err := d.take(8)
+		if err != nil {
+			return err
+		}
+		v.SetUint(binary.LittleEndian.Uint64(b))
+		return nil
+	case reflect.String:
+		n, err := d.length()
+		if err != nil {
+			return err
+		}
+		b, _ := d.take(n)
+		v.SetString(string(b))
+		return nil
+	case reflect.Slice:
+		n, err := d.length()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			v.SetZero() // Empty slices decode as nil, like omitted JSON arrays
+			return nil
+		}
+		s := reflect.MakeSlice(v.Type(), n, n)
+		for i := range n {
+			if err := d.value(s.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+		return nil
+	case reflect.Pointer:
+		present, err := d.bool()
+		if err != nil || !present {
+			v.SetZero()
+			return err
+		}
+		p := reflect.New(v.Type().Elem())
+		if err := d.value(p.Elem()); err != nil {
+			return err
+		}
+		v.Set(p)
+		return nil
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if err := d.value(v.Field(i)); err != nil {
+				return fmt.Errorf("%s.%s: %w", v.Type().Name(), v.Type().Field(i).Name, err)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("cannot decode values of type %s", v.Type())
+}
@@ -0,0 +1,88 @@
+package binproto
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestMarshal_RoundTrip(t *testing.T) {
+	want := Batch{
+		Game: Game{
+			ID:   "abc",
+			Seed: 1<<63 + 5,
+			Game: State{
+				Status:  "Ongoing",
+				Round:   3,
+				Players: []Player{{Status: "Active", Money: -7}, {Status: "Lost", Reason: "Bankrupt"}},
+			},
+			PossibleActions: []Action{{Type: "Finished", PlayerIndex: 1, AssetType: "Renewable"}},
+			Rewards:         &Rewards{Terminal: []int32{0, 1}, Shaped: []int32{-2, 2}},
+		},
+		Results: []ActionResult{{Status: "Rejected", Error: &ErrorResponse{Code: 2, Error: "no"}}},
+	}
+
+	data, err := Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var got Batch
+	if err := Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Got %+v, want %+v", got, want)
+	}
+}
+
+func TestUnmarshal_Invalid(t *testing.T) {
+	data, err := Marshal(StateRequest{ID: "abc", Token: "def"})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "truncated", data: data[:len(data)-1]},
+		{name: "left over bytes", data: append(bytes.Clone(data), 0)},
+		{name: "string longer than message", data: []byte{0xff, 0xff, 0, 0, 'a'}},
+		{name: "invalid bool", data: append(bytes.Clone(data[:len(data)-1]), 2)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got StateRequest
+			if err := Unmarshal(tt.data, &got); err == nil {
+				t.Errorf("Unmarshal() succeeded with %+v, want error", got)
+			}
+		})
+	}
+}
+
+func TestMarshal_UnsupportedType(t *testing.T) {
+	if _, err := Marshal(struct{ N int }{1}); err == nil {
+		t.Errorf("Marshal() succeeded for an int field, want error")
+	}
+}
+
+func TestReadFrame(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteFrame(&buf, []byte("hello")); err != nil {
+		t.Fatalf("WriteFrame() error = %v", err)
+	}
+	whole := bytes.Clone(buf.Bytes())
+
+	got, err := ReadFrame(&buf)
+	if err != nil || string(got) != "hello" {
+		t.Errorf("ReadFrame() = %q, %v, want %q", got, err, "hello")
+	}
+	if _, err := ReadFrame(&buf); !errors.Is(err, io.EOF) {
+		t.Errorf("ReadFrame() at the end error = %v, want io.EOF", err)
+	}
+	if _, err := ReadFrame(bytes.NewReader(whole[:6])); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("ReadFrame() of a truncated frame error = %v, want io.ErrUnexpectedEOF", err)
+	}
+}
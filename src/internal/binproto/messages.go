@@ -0,0 +1,187 @@
+package binproto
+
+// Op identifies the operation of a request. Each op matches one REST endpoint, except for the event stream, which
+// clients of the binary protocol replace by waiting for the state with StateRequest.Wait and reading the log from an
+// offset.
+type Op uint8
+
+const (
+	OpListGames      Op = iota + 1 // GET /
+	OpNewGame                      // POST /new
+	OpGetState                     // GET /g/{id}
+	OpAction                       // POST /g/{id}/action
+	OpActions                      // POST /g/{id}/actions
+	OpGetLog                       // GET /g/{id}/log
+	OpDeleteGame                   // DELETE /g/{id}
+	OpGetParams                    // GET /g/{id}/params
+	OpDefaultParams                // GET /params/default
+	OpValidateParams               // POST /params/validate
+)
+
+// OpInfo describes the messages of an op.
+type OpInfo struct {
+	Op       Op
+	Name     string // Name of the op in generated clients
+	Doc      string
+	Request  any // Zero value of the request message
+	Response any // Zero value of the response message for status 200
+}
+
+// Ops lists every op, in order.
+var Ops = []OpInfo{
+	{OpListGames, "ListGames", "Returns the IDs of all games on the server.", Empty{}, GameIDs{}},
+	{OpNewGame, "NewGame", "Creates a game, and returns its initial state.", NewGameRequest{}, Game{}},
+	{OpGetState, "GetState", "Returns the state of a game observable by the seat of the token.", StateRequest{}, Game{}},
+	{OpAction, "Action", "Applies an action, and returns the new state with the rewards for the action.", ActionRequest{}, Game{}},
+	{OpActions, "Actions", "Applies a batch of actions, and returns the new state with the result of each action.", ActionsRequest{}, Batch{}},
+	{OpGetLog, "GetLog", "Returns the events of a game's log, optionally filtered and paginated.", LogRequest{}, Log{}},
+	{OpDeleteGame, "DeleteGame", "Deletes a game. It is a no-op if the game doesn't exist.", GameRequest{}, Empty{}},
+	{OpGetParams, "GetParams", "Returns the parameters of a game.", GameRequest{}, Params{}},
+	{OpDefaultParams, "DefaultParams", "Returns the parameters used for parts of a new game's parameters that aren't set.", Empty{}, Params{}},
+	{OpValidateParams, "ValidateParams", "Lists every reason that full or partial game parameters are invalid.", Params{}, Validation{}},
+}
+
+// Fields tagged `binproto:"optional"` may be left unset in requests, and default to their zero value in generated
+// clients. Enums are sent by name, as in the REST API.
+
+// Empty is the message of ops with nothing to send.
+type Empty struct{}
+
+type GameIDs struct {
+	IDs []string
+}
+
+type NewGameRequest struct {
+	NumPlayers int32
+	Seed       *uint64 `binproto:"optional"` // Random if not set
+	SeatTokens bool    `binproto:"optional"` // Whether to create a bearer token for each seat
+	Visibility string  `binproto:"optional"` // Name of the core.Visibility policy, or empty for Full
+	Params     string  `binproto:"optional"` // Full or partial params.Params as JSON, merged over the defaults
+}
+
+// GameRequest is the message of ops which only need a game, and for games with seats a seat token.
+type GameRequest struct {
+	ID    string
+	Token string `binproto:"optional"`
+}
+
+type StateRequest struct {
+	ID    string
+	Token string `binproto:"optional"`
+	Wait  bool   `binproto:"optional"` // Wait until the seat has possible actions or the game is over
+}
+
+type ActionRequest struct {
+	ID     string
+	Token  string `binproto:"optional"`
+	Action Action
+}
+
+type ActionsRequest struct {
+	ID      string
+	Token   string `binproto:"optional"`
+	Actions []Action
+}
+
+// LogRequest selects events from a game log like the query parameters of the REST log endpoint. The log shows what
+// the seat of the token may see under the game's visibility policy.
+type LogRequest struct {
+	ID            string
+	Token         string   `binproto:"optional"`
+	GameEvents    []string `binproto:"optional"`
+	Rounds        []int32  `binproto:"optional"`
+	PlayerIndices []int32  `binproto:"optional"`
+	States        []string `binproto:"optional"`
+	Offset        int32    `binproto:"optional"`
+	Limit         int32    `binproto:"optional"`
+}
+
+// Log holds one JSON object per event.
+type Log struct {
+	Events []string
+}
+
+// Params holds full or partial params.Params as JSON.
+type Params struct {
+	JSON string
+}
+
+type Validation struct {
+	Valid  bool
+	Errors []string
+}
+
+type Action struct {
+	Type        string // Name of the engine.ActionType
+	PlayerIndex int32
+	AssetType   string // Name of the assets.Type
+	Cost        int32
+}
+
+type AssetMix struct {
+	Renewables         int32
+	BatteriesArbitrage int32
+	BatteriesCapacity  int32
+	FossilsWholesale   int32
+	FossilsCapacity    int32
+}
+
+type Snapshot struct {
+	AssetMix        AssetMix
+	PriceVolatility int32
+	GridStability   int32
+}
+
+type Player struct {
+	Status string
+	Reason string // Empty while the player is active
+	Money  int32  // Reads as 0 if the visibility policy hides it
+	Assets AssetMix
+}
+
+type State struct {
+	Status            string
+	Reason            string
+	Round             int32
+	EmissionsCounter  int32
+	Players           []Player
+	LastRoundSnapshot Snapshot
+	TakeoverPool      AssetMix
+	Visibility        string
+}
+
+// Rewards holds each player's reward for the last action under every core.RewardScheme.
+type Rewards struct {
+	Terminal   []int32
+	MoneyDelta []int32 // Opponents' deltas read as 0 if the visibility policy hides their money
+	Shaped     []int32
+}
+
+type Game struct {
+	ID              string
+	Seed            uint64
+	Game            State
+	PossibleActions []Action
+	Rewards         *Rewards // Only set in responses to actions
+	SeatTokens      []string // Only set in responses creating a game with seat tokens
+}
+
+type ActionResult struct {
+	Action  Action
+	Status  string         // Applied, Rejected or Skipped
+	Rewards *Rewards       // Only set for applied actions
+	Error   *ErrorResponse // Only set for rejected actions
+}
+
+type Batch struct {
+	Game    Game
+	Results []ActionResult
+}
+
+// ErrorResponse is the message of every response with a status other than 200. Code is a compact/game ErrCode.
+type ErrorResponse struct {
+	Code         int32
+	Error        string
+	Action       *Action // The rejected action, for invalid actions
+	LegalActions []Action
+}
@@ -96,12 +96,14 @@ type Package struct {
 	Name    string
 	PkgPath string
 	Exports []WasmExportedFunc
+	Enums   []Enum
 
 	fset     *token.FileSet
+	types    *types.Package
 	typeInfo *types.Info
 }
 
-// Load returns all packages with WASM generator information
+// Load returns all packages with WASM generator information, i.e. exported functions or enums
 func Load(pattern string) ([]Package, error) {
 	cfg := packages.Config{Mode: LoadMode}
 	pkgs, err := packages.Load(&cfg, pattern)
@@ -114,6 +116,7 @@ func Load(pattern string) ([]Package, error) {
 			Name:     pkg.Name,
 			PkgPath:  pkg.PkgPath,
 			fset:     pkg.Fset,
+			types:    pkg.Types,
 			typeInfo: pkg.TypesInfo,
 		}
 		for _, astFile := range pkg.Syntax {
@@ -121,10 +124,13 @@ func Load(pattern string) ([]Package, error) {
 				if err := p.maybeAddWasmExportedFunc(d); err != nil {
 					log.Printf("warn: %s", err)
 				}
+				if err := p.maybeAddEnums(d); err != nil {
+					log.Printf("warn: %s", err)
+				}
 			}
 
 		}
-		if len(p.Exports) != 0 {
+		if len(p.Exports) != 0 || len(p.Enums) != 0 {
 			pres = append(pres, p)
 		}
 	}
@@ -0,0 +1,113 @@
+package wasmcodegen
+
+import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+)
+
+const (
+	// enumDirective marks a named integer type whose constants should be generated as a Python enum.IntEnum.
+	// An optional argument gives the prefix to trim from constant names, which defaults to the type name.
+	enumDirective = "enum"
+	// flagDirective is like enumDirective, but generates an enum.IntFlag for bitmask types.
+	flagDirective = "flag"
+)
+
+type EnumValue struct {
+	// Go constant name with the enum prefix trimmed
+	Name  string
+	Value int64
+}
+
+func (ev EnumValue) ScreamingSnakeName() string {
+	return strcase.ToScreamingSnake(ev.Name)
+}
+
+type Enum struct {
+	// Go type name
+	Name string
+	// Whether the values are bit flags
+	Flag   bool
+	Values []EnumValue
+}
+
+// PythonBase returns the Python enum base class for the enum.
+func (e Enum) PythonBase() string {
+	if e.Flag {
+		return "enum.IntFlag"
+	}
+	return "enum.IntEnum"
+}
+
+// maybeAddEnums adds an Enum for each type declared in decl which has a //pybindgen:enum or //pybindgen:flag directive
+func (p *Package) maybeAddEnums(decl ast.Decl) error {
+	gd, ok := decl.(*ast.GenDecl)
+	if !ok || gd.Tok != token.TYPE {
+		return nil // Node was not a type declaration
+	}
+	for _, spec := range gd.Specs {
+		ts := spec.(*ast.TypeSpec)
+		doc := ts.Doc
+		if doc == nil && len(gd.Specs) == 1 {
+			doc = gd.Doc // Doc comments for unparenthesized declarations are attached to the GenDecl
+		}
+		if doc == nil {
+			continue // Type has no doc comments (thus no directives apply)
+		}
+
+		var e Enum
+		var directive, prefix string
+		for _, c := range doc.List {
+			if d, ok := ast.ParseDirective(c.Pos(), c.Text); ok && d.Tool == "pybindgen" && (d.Name == enumDirective || d.Name == flagDirective) {
+				directive = d.Name
+				prefix = strings.TrimSpace(d.Args)
+			}
+		}
+		if directive == "" {
+			continue
+		}
+		e.Flag = directive == flagDirective
+		e.Name = ts.Name.Name
+		if prefix == "" {
+			prefix = e.Name
+		}
+
+		typeName, ok := p.typeInfo.Defs[ts.Name].(*types.TypeName)
+		if !ok {
+			return fmt.Errorf("%s: cannot get type of %s", p.fset.Position(ts.Pos()), e.Name)
+		}
+		if basic, ok := typeName.Type().Underlying().(*types.Basic); !ok || basic.Info()&types.IsInteger == 0 {
+			return fmt.Errorf("%s: //pybindgen:%s directive on non-integer type %s", p.fset.Position(ts.Pos()), directive, e.Name)
+		}
+
+		// Find all constants of the type declared in the package
+		scope := p.types.Scope()
+		for _, name := range scope.Names() {
+			c, ok := scope.Lookup(name).(*types.Const)
+			if !ok || !types.Identical(c.Type(), typeName.Type()) {
+				continue
+			}
+			v, exact := constant.Int64Val(c.Val())
+			if !exact {
+				return fmt.Errorf("%s: value of %s does not fit in int64", p.fset.Position(c.Pos()), name)
+			}
+			e.Values = append(e.Values, EnumValue{Name: strings.TrimPrefix(name, prefix), Value: v})
+		}
+		if len(e.Values) == 0 {
+			return fmt.Errorf("%s: no constants found for %s", p.fset.Position(ts.Pos()), e.Name)
+		}
+		slices.SortFunc(e.Values, func(a, b EnumValue) int {
+			return cmp.Or(cmp.Compare(a.Value, b.Value), cmp.Compare(a.Name, b.Name))
+		})
+		p.Enums = append(p.Enums, e)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/WillMorrison/JouleQuestCardGame/core"
 )
 
+//pybindgen:enum
 type CapacityRule int
 
 //go:generate go tool stringer -type=CapacityRule -trimprefix=CapacityRule
@@ -20,6 +21,7 @@ const (
 	CapacityRuleSharedCapacityPaymentPool
 )
 
+//pybindgen:enum
 type CarbonTaxRule int
 
 //go:generate go tool stringer -type=CarbonTaxRule -trimprefix=CarbonTaxRule
@@ -31,6 +33,7 @@ const (
 	CarbonTaxRuleApplyCarbonTax
 )
 
+//pybindgen:enum
 type WinConditionRule int
 
 //go:generate go tool stringer -type=WinConditionRule -trimprefix=WinConditionRule
@@ -42,6 +45,7 @@ const (
 	WinConditionRuleRenewablePenetrationThreshold
 )
 
+//pybindgen:enum
 type GenerationConstraintRule int
 
 //go:generate go tool stringer -type=GenerationConstraintRule -trimprefix=GenerationConstraintRule
@@ -53,6 +57,7 @@ const (
 	GenerationConstraintRuleMaxDecrease
 )
 
+//pybindgen:enum
 type TakeoverRule int
 
 //go:generate go tool stringer -type=TakeoverRule -trimprefix=TakeoverRule
@@ -78,6 +78,28 @@ func isElementwiseGreaterAndLesser(a, b core.PnLTable, namea, nameb string) erro
 	return errors.Join(errs...)
 }
 
+// ValidationErrors returns each reason that the parameters aren't sensible as a separate error, or nil if they are
+// valid.
+func (p Params) ValidationErrors() []error {
+	return splitJoined(p.Valid())
+}
+
+// splitJoined returns the errors joined into err, recursively, or err alone if it does not join several errors.
+func splitJoined(err error) []error {
+	if err == nil {
+		return nil
+	}
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{err}
+	}
+	var errs []error
+	for _, e := range joined.Unwrap() {
+		errs = append(errs, splitJoined(e)...)
+	}
+	return errs
+}
+
 // Valid returns an error if the parameters aren't sensible
 func (p Params) Valid() error {
 	var errs []error
@@ -108,6 +130,12 @@ func (p Params) Valid() error {
 	default:
 		errs = append(errs, fmt.Errorf("generation constraint rule is not valid"))
 	}
+	switch p.TakeoverRule {
+	case TakeoverRuleForcedTakeover, TakeoverRuleVirtualOwner:
+		break
+	default:
+		errs = append(errs, fmt.Errorf("takeover rule is not valid"))
+	}
 
 	// Check that PnL does the right thing based on volatility
 	errs = append(errs, isDecreasing(p.RenewablePnL, "RenewablePnL"))
@@ -164,6 +192,19 @@ func (p Params) Valid() error {
 		errs = append(errs, fmt.Errorf("player cannot keep generation constant in round one by scrapping a fossil and building a renewable: scrap cost (%d) + build cost (%d) > starting money (%d)", p.FossilScrapCost, p.RenewableBuildCost, p.InitialCash))
 	}
 
+	// Check that starting assets are usable at all. Later checks divide by the number of starting assets.
+	if len(p.StartingFossilAssetsPerPlayer) == 0 {
+		errs = append(errs, fmt.Errorf("starting fossil assets should be set for at least one number of players"))
+	}
+	for numPlayers, numFossil := range p.StartingFossilAssetsPerPlayer {
+		if numPlayers < 2 {
+			errs = append(errs, fmt.Errorf("starting fossil assets set for %d players, should be at least 2 players", numPlayers))
+		}
+		if numFossil <= 0 {
+			errs = append(errs, fmt.Errorf("starting fossil assets for %d players (%d) should be greater than 0", numPlayers, numFossil))
+		}
+	}
+
 	// Check that starting assets meet minimum generation
 	for numPlayers, numFossil := range p.StartingFossilAssetsPerPlayer {
 		if numFossil*numPlayers <= p.GenerationConstraint {
@@ -187,6 +228,9 @@ func (p Params) Valid() error {
 
 	// Check that the emissions cap is reasonable
 	for numPlayers, numFossil := range p.StartingFossilAssetsPerPlayer {
+		if numFossil <= 0 || numPlayers <= 0 {
+			continue // Already reported above
+		}
 		if numFossil*(numFossil+1)/2*numPlayers >= p.EmissionsCap {
 			errs = append(errs, fmt.Errorf("emissions cap (%d) would be exceeded by %d players starting with %d fossil assets and scrapping one per round. Raise the cap", p.EmissionsCap, numPlayers, numFossil))
 		}
@@ -2,6 +2,8 @@ package params
 
 import (
 	"testing"
+
+	"github.com/WillMorrison/JouleQuestCardGame/core"
 )
 
 func TestParams_Valid(t *testing.T) {
@@ -20,6 +22,21 @@ func TestParams_Valid(t *testing.T) {
 			params:  Params{},
 			wantErr: true,
 		},
+		{
+			name:    "invalid takeover rule",
+			params:  BuilderFrom(Default).TakeoverRule(TakeoverRule(99)).Build(),
+			wantErr: true,
+		},
+		{
+			name:    "invalid zero starting assets",
+			params:  BuilderFrom(Default).StartingAssets(map[int]int{2: 0}).Build(),
+			wantErr: true,
+		},
+		{
+			name:    "invalid single player game",
+			params:  BuilderFrom(Default).StartingAssets(map[int]int{1: 20}).Build(),
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -36,3 +53,24 @@ func TestParams_Valid(t *testing.T) {
 		})
 	}
 }
+
+func TestParams_ValidationErrors(t *testing.T) {
+	if errs := Default.ValidationErrors(); errs != nil {
+		t.Errorf("ValidationErrors() = %v for the default params, want nil", errs)
+	}
+
+	p := BuilderFrom(Default).TakeoverRule(TakeoverRule(99)).Build()
+	p.RenewablePnL = core.PnLTable{0, 1, 2, 3}
+	errs := p.ValidationErrors()
+
+	// The takeover rule, one for each of the 3 adjacent volatilities where RenewablePnL isn't decreasing, and one as
+	// renewables can't lose money
+	if len(errs) != 5 {
+		t.Errorf("ValidationErrors() returned %d errors, want 5: %q", len(errs), errs)
+	}
+	for _, err := range errs {
+		if _, joined := err.(interface{ Unwrap() []error }); joined {
+			t.Errorf("ValidationErrors() returned joined error %q", err)
+		}
+	}
+}